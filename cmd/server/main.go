@@ -2,51 +2,50 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/config"
 	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/container"
+	"github.com/gdugdh24/mpit2026-backend/pkg/logger"
 )
 
-func maskSecret(secret string) string {
-	if len(secret) <= 4 {
-		return "****"
-	}
-	return secret[:4] + "****" + secret[len(secret)-4:]
-}
+// shutdownTimeout bounds how long in-flight HTTP requests may take to finish
+const shutdownTimeout = 10 * time.Second
 
 func main() {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Printf("Failed to load config: %v\n", err)
+		logger.New(os.Stdout, "").Error("failed to load config", "error", err)
 		os.Exit(1)
 	}
 
-	// Print configuration (without secrets)
-	fmt.Printf("=== Configuration ===\n")
-	fmt.Printf("Server: %s:%d (env: %s)\n", cfg.Server.Host, cfg.Server.Port, cfg.Server.Env)
-	fmt.Printf("Database: %s:%d/%s (user: %s, ssl: %s)\n",
-		cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName,
-		cfg.Database.User, cfg.Database.SSLMode)
-	fmt.Printf("Redis: %s:%d (db: %d)\n", cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.DB)
-	fmt.Printf("VK: App ID: %d, Secret Key: %s\n", cfg.VK.AppID, maskSecret(cfg.VK.SecretKey))
-	fmt.Printf("Storage: %s (%s)\n", cfg.Storage.Type, cfg.Storage.Path)
-	fmt.Printf("Log Level: %s\n", cfg.Logging.Level)
-	fmt.Printf("====================\n\n")
+	log := logger.New(os.Stdout, cfg.Logging.Level)
+
+	// Log configuration (secrets are never logged)
+	log.Info("configuration",
+		slog.Group("server", "host", cfg.Server.Host, "port", cfg.Server.Port, "env", cfg.Server.Env),
+		slog.Group("database", "host", cfg.Database.Host, "port", cfg.Database.Port,
+			"name", cfg.Database.DBName, "user", cfg.Database.User, "ssl", cfg.Database.SSLMode),
+		slog.Group("redis", "enabled", cfg.Redis.Enabled, "host", cfg.Redis.Host, "port", cfg.Redis.Port, "db", cfg.Redis.DB),
+		slog.Group("vk", "app_id", cfg.VK.AppID),
+		slog.Group("storage", "type", cfg.Storage.Type, "path", cfg.Storage.Path),
+		"log_level", logger.ParseLevel(cfg.Logging.Level).String(),
+	)
 
 	// Initialize dependency injection container
-	app, err := container.NewContainer(cfg)
+	app, err := container.NewContainer(cfg, log)
 	if err != nil {
-		fmt.Printf("Failed to initialize application: %v\n", err)
+		log.Error("failed to initialize application", "error", err)
 		os.Exit(1)
 	}
 	defer func() {
 		if err := app.Close(); err != nil {
-			fmt.Printf("Error closing application: %v\n", err)
+			log.Error("failed to close application", "error", err)
 		}
 	}()
 
@@ -57,25 +56,24 @@ func main() {
 	// Start server in a goroutine
 	go func() {
 		if err := app.Server.Start(); err != nil {
-			fmt.Printf("Server error: %v\n", err)
+			log.Error("server error", "error", err)
 			quit <- syscall.SIGTERM
 		}
 	}()
 
-	fmt.Printf("Server started successfully on %s:%d\n", cfg.Server.Host, cfg.Server.Port)
-	fmt.Println("Press Ctrl+C to stop")
+	log.Info("server started", "host", cfg.Server.Host, "port", cfg.Server.Port)
 
 	// Wait for interrupt signal
 	<-quit
 
-	// Graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 10)
+	// Graceful shutdown, the deferred Close then drains background jobs
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
 	if err := app.Server.Shutdown(ctx); err != nil {
-		fmt.Printf("Server shutdown error: %v\n", err)
-		os.Exit(1)
+		log.Error("server shutdown failed", "error", err)
+		return
 	}
 
-	fmt.Println("Server exited properly")
+	log.Info("server exited")
 }
@@ -8,14 +8,20 @@ import (
 )
 
 type Config struct {
-	Server         ServerConfig
-	Database       DatabaseConfig
-	Redis          RedisConfig
-	JWT            JWTConfig
-	Encryption     EncryptionConfig
-	Storage        StorageConfig
-	Logging        LoggingConfig
-	GeminiAPIKey string
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	JWT        JWTConfig
+	Encryption EncryptionConfig
+	Storage    StorageConfig
+	Logging    LoggingConfig
+	Tracing    TracingConfig
+	Jobs       JobsConfig
+	VK         VKConfig
+	Swipe      SwipeConfig
+	ML         MLConfig
+	AI         AIConfig
+}
 
 type ServerConfig struct {
 	Host         string
@@ -23,6 +29,11 @@ type ServerConfig struct {
 	Env          string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	// DevAuthSecret protects development-only endpoints such as /auth/test
+	DevAuthSecret string
+	// MetricsPort serves /metrics on its own listener that is not published
+	// publicly, 0 disables it
+	MetricsPort int
 }
 
 type DatabaseConfig struct {
@@ -35,6 +46,7 @@ type DatabaseConfig struct {
 }
 
 type RedisConfig struct {
+	Enabled  bool
 	Host     string
 	Port     int
 	Password string
@@ -51,6 +63,35 @@ type JWTConfig struct {
 type VKConfig struct {
 	SecretKey string
 	AppID     int
+	// LaunchParamsMaxAge is how old vk_ts in launch params may be
+	LaunchParamsMaxAge time.Duration
+	// APIBaseURL overrides the VK API endpoint, empty means the public API
+	APIBaseURL string
+}
+
+type SwipeConfig struct {
+	// UndoWindow is how long after a swipe it can still be undone
+	UndoWindow time.Duration
+	// Daily limits reset at midnight in the user's timezone, 0 disables a limit
+	DailyLikeLimit      int
+	DailySuperLikeLimit int
+}
+
+type MLConfig struct {
+	// ServiceURL is the base URL of the ML embedding service, empty skips its readiness check
+	ServiceURL string
+}
+
+type AIConfig struct {
+	// Provider is gemini, openai (any OpenAI-compatible server), fake or none
+	Provider string
+	// Model overrides the provider's default model, required for openai
+	Model string
+	// BaseURL of the OpenAI-compatible API, e.g. http://localhost:11434/v1 for Ollama
+	BaseURL string
+	// APIKey is sent to the OpenAI-compatible API, local servers usually don't need it
+	APIKey       string
+	GeminiAPIKey string
 }
 
 type EncryptionConfig struct {
@@ -58,29 +99,74 @@ type EncryptionConfig struct {
 }
 
 type StorageConfig struct {
+	// Type selects the storage driver, only "local" is supported for now
 	Type string
 	Path string
+	// PublicURL is the URL prefix stored files are served under
+	PublicURL string
+	// MaxUploadBytes limits the size of a single uploaded photo
+	MaxUploadBytes int64
 }
 
 type LoggingConfig struct {
 	Level string
 }
 
+type JobsConfig struct {
+	// Workers is how many background jobs this instance runs at once
+	Workers int
+	// DrainTimeout is how long shutdown waits for running jobs
+	DrainTimeout time.Duration
+}
+
+type TracingConfig struct {
+	// Exporter is none, stdout (local debugging) or otlp
+	Exporter string
+	// OTLPEndpoint is the collector's OTLP/HTTP host:port
+	OTLPEndpoint string
+	// OTLPInsecure sends spans over plain HTTP
+	OTLPInsecure bool
+	// SampleRatio is the share of new traces that are recorded, 0..1
+	SampleRatio float64
+	ServiceName string
+}
+
 // Load loads configuration from environment variables or .env file
 func Load() (*Config, error) {
 	viper.SetConfigFile(".env")
 	viper.AutomaticEnv()
+	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("METRICS_PORT", 9090)
+	viper.SetDefault("TRACING_EXPORTER", "none")
+	viper.SetDefault("JOBS_WORKERS", 4)
+	viper.SetDefault("JOBS_DRAIN_TIMEOUT_SEC", 30)
+	viper.SetDefault("TRACING_OTLP_ENDPOINT", "localhost:4318")
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	viper.SetDefault("TRACING_SERVICE_NAME", "dating-backend")
+	viper.SetDefault("JWT_ACCESS_EXPIRY_MIN", 15)
+	viper.SetDefault("JWT_REFRESH_EXPIRY_DAY", 30)
+	viper.SetDefault("VK_LAUNCH_PARAMS_MAX_AGE_MIN", 60)
+	viper.SetDefault("SWIPE_UNDO_WINDOW_SEC", 30)
+	viper.SetDefault("SWIPE_DAILY_LIKE_LIMIT", 100)
+	viper.SetDefault("SWIPE_DAILY_SUPER_LIKE_LIMIT", 1)
+	viper.SetDefault("STORAGE_TYPE", "local")
+	viper.SetDefault("STORAGE_PATH", "./uploads")
+	viper.SetDefault("STORAGE_PUBLIC_URL", "/uploads")
+	viper.SetDefault("STORAGE_MAX_UPLOAD_MB", 10)
+	viper.SetDefault("AI_PROVIDER", "gemini")
 
 	// Try to read from .env file, but don't fail if it doesn't exist
 	_ = viper.ReadInConfig()
 
 	config := &Config{
 		Server: ServerConfig{
-			Host:         viper.GetString("SERVER_HOST"),
-			Port:         viper.GetInt("SERVER_PORT"),
-			Env:          viper.GetString("ENV"),
-			ReadTimeout:  15 * time.Second,
-			WriteTimeout: 15 * time.Second,
+			Host:          viper.GetString("SERVER_HOST"),
+			Port:          viper.GetInt("SERVER_PORT"),
+			Env:           viper.GetString("ENV"),
+			ReadTimeout:   15 * time.Second,
+			WriteTimeout:  15 * time.Second,
+			DevAuthSecret: viper.GetString("DEV_AUTH_SECRET"),
+			MetricsPort:   viper.GetInt("METRICS_PORT"),
 		},
 		Database: DatabaseConfig{
 			Host:     viper.GetString("DB_HOST"),
@@ -91,6 +177,7 @@ func Load() (*Config, error) {
 			SSLMode:  viper.GetString("DB_SSL_MODE"),
 		},
 		Redis: RedisConfig{
+			Enabled:  viper.GetBool("REDIS_ENABLED"),
 			Host:     viper.GetString("REDIS_HOST"),
 			Port:     viper.GetInt("REDIS_PORT"),
 			Password: viper.GetString("REDIS_PASSWORD"),
@@ -103,20 +190,49 @@ func Load() (*Config, error) {
 			RefreshExpiryDay: viper.GetInt("JWT_REFRESH_EXPIRY_DAY"),
 		},
 		VK: VKConfig{
-			SecretKey: viper.GetString("VK_SECRET_KEY"),
-			AppID:     viper.GetInt("VK_APP_ID"),
+			SecretKey:          viper.GetString("VK_SECRET_KEY"),
+			AppID:              viper.GetInt("VK_APP_ID"),
+			LaunchParamsMaxAge: time.Duration(viper.GetInt("VK_LAUNCH_PARAMS_MAX_AGE_MIN")) * time.Minute,
+			APIBaseURL:         viper.GetString("VK_API_BASE_URL"),
+		},
+		Swipe: SwipeConfig{
+			UndoWindow:          time.Duration(viper.GetInt("SWIPE_UNDO_WINDOW_SEC")) * time.Second,
+			DailyLikeLimit:      viper.GetInt("SWIPE_DAILY_LIKE_LIMIT"),
+			DailySuperLikeLimit: viper.GetInt("SWIPE_DAILY_SUPER_LIKE_LIMIT"),
+		},
+		ML: MLConfig{
+			ServiceURL: viper.GetString("ML_SERVICE_URL"),
 		},
 		Encryption: EncryptionConfig{
 			AESKey: viper.GetString("AES_ENCRYPTION_KEY"),
 		},
 		Storage: StorageConfig{
-			Type: viper.GetString("STORAGE_TYPE"),
-			Path: viper.GetString("STORAGE_PATH"),
+			Type:           viper.GetString("STORAGE_TYPE"),
+			Path:           viper.GetString("STORAGE_PATH"),
+			PublicURL:      viper.GetString("STORAGE_PUBLIC_URL"),
+			MaxUploadBytes: viper.GetInt64("STORAGE_MAX_UPLOAD_MB") << 20,
 		},
 		Logging: LoggingConfig{
 			Level: viper.GetString("LOG_LEVEL"),
 		},
-		GeminiAPIKey: viper.GetString("GEMINI_API_KEY"),
+		Jobs: JobsConfig{
+			Workers:      viper.GetInt("JOBS_WORKERS"),
+			DrainTimeout: time.Duration(viper.GetInt("JOBS_DRAIN_TIMEOUT_SEC")) * time.Second,
+		},
+		Tracing: TracingConfig{
+			Exporter:     viper.GetString("TRACING_EXPORTER"),
+			OTLPEndpoint: viper.GetString("TRACING_OTLP_ENDPOINT"),
+			OTLPInsecure: viper.GetBool("TRACING_OTLP_INSECURE"),
+			SampleRatio:  viper.GetFloat64("TRACING_SAMPLE_RATIO"),
+			ServiceName:  viper.GetString("TRACING_SERVICE_NAME"),
+		},
+		AI: AIConfig{
+			Provider:     viper.GetString("AI_PROVIDER"),
+			Model:        viper.GetString("AI_MODEL"),
+			BaseURL:      viper.GetString("AI_BASE_URL"),
+			APIKey:       viper.GetString("AI_API_KEY"),
+			GeminiAPIKey: viper.GetString("GEMINI_API_KEY"),
+		},
 	}
 
 	// Validate critical configuration
@@ -156,9 +272,31 @@ func (c *Config) Validate() error {
 	if len(c.VK.SecretKey) < 16 {
 		return fmt.Errorf("VK secret key must be at least 16 characters")
 	}
+	if c.VK.AppID == 0 {
+		return fmt.Errorf("VK app ID is required")
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		return fmt.Errorf("unknown tracing exporter %q, expected none, stdout or otlp", c.Tracing.Exporter)
+	}
+	switch c.AI.Provider {
+	case "gemini", "fake", "none":
+	case "openai":
+		if c.AI.BaseURL == "" || c.AI.Model == "" {
+			return fmt.Errorf("AI base URL and model are required for the openai provider")
+		}
+	default:
+		return fmt.Errorf("unknown AI provider %q, expected gemini, openai, fake or none", c.AI.Provider)
+	}
 	return nil
 }
 
+// IsDevelopment reports whether the server runs in development or test environment
+func (c *ServerConfig) IsDevelopment() bool {
+	return c.Env == "development" || c.Env == "test"
+}
+
 // GetDSN returns PostgreSQL connection string
 func (c *DatabaseConfig) GetDSN() string {
 	return fmt.Sprintf(
@@ -0,0 +1,567 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/admin"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	adminUseCase *admin.AdminUseCase
+}
+
+func NewAdminHandler(adminUseCase *admin.AdminUseCase) *AdminHandler {
+	return &AdminHandler{
+		adminUseCase: adminUseCase,
+	}
+}
+
+// SearchUsers handles GET /admin/users
+// @Summary Find user by VK ID
+// @Description Look up a user with their profile and moderation flags by VK ID
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param vk_id query int true "VK user ID"
+// @Success 200 {object} admin.UserDetails
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users [get]
+func (h *AdminHandler) SearchUsers(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	vkID, err := strconv.Atoi(c.Query("vk_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid vk_id",
+		})
+		return
+	}
+
+	details, err := h.adminUseCase.SearchByVKID(c.Request.Context(), actor, vkID)
+	if err != nil {
+		respondAdminError(c, err, "failed to find user")
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// GetUser handles GET /admin/users/:id
+// @Summary Get user
+// @Description Get a user with their profile and moderation flags
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} admin.UserDetails
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := pathID(c, "id", "invalid user id")
+	if !ok {
+		return
+	}
+
+	details, err := h.adminUseCase.GetUser(c.Request.Context(), actor, userID)
+	if err != nil {
+		respondAdminError(c, err, "failed to get user")
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// GetUserSwipes handles GET /admin/users/:id/swipes
+// @Summary Get user swipes
+// @Description Get swipes made by a user, newest first
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "User ID"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} domain.Swipe
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/swipes [get]
+func (h *AdminHandler) GetUserSwipes(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := pathID(c, "id", "invalid user id")
+	if !ok {
+		return
+	}
+
+	limit, offset := adminPage(c)
+	swipes, err := h.adminUseCase.GetUserSwipes(c.Request.Context(), actor, userID, limit, offset)
+	if err != nil {
+		respondAdminError(c, err, "failed to get swipes")
+		return
+	}
+
+	c.JSON(http.StatusOK, swipes)
+}
+
+// GetUserMatches handles GET /admin/users/:id/matches
+// @Summary Get user matches
+// @Description Get a user's matches including inactive ones, newest first
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "User ID"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} domain.Match
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/matches [get]
+func (h *AdminHandler) GetUserMatches(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := pathID(c, "id", "invalid user id")
+	if !ok {
+		return
+	}
+
+	limit, offset := adminPage(c)
+	matches, err := h.adminUseCase.GetUserMatches(c.Request.Context(), actor, userID, limit, offset)
+	if err != nil {
+		respondAdminError(c, err, "failed to get matches")
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
+
+// BanUser handles POST /admin/users/:id/ban
+// @Summary Ban user
+// @Description Ban a user and end all of their sessions (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body admin.ModerationRequest false "Reason"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/ban [post]
+func (h *AdminHandler) BanUser(c *gin.Context) {
+	h.setBanned(c, true)
+}
+
+// UnbanUser handles POST /admin/users/:id/unban
+// @Summary Unban user
+// @Description Lift a user's ban (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body admin.ModerationRequest false "Reason"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/unban [post]
+func (h *AdminHandler) UnbanUser(c *gin.Context) {
+	h.setBanned(c, false)
+}
+
+func (h *AdminHandler) setBanned(c *gin.Context, banned bool) {
+	actor, userID, req, ok := moderationInput(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUseCase.SetBanned(c.Request.Context(), actor, userID, banned, req); err != nil {
+		respondAdminError(c, err, "failed to update ban")
+		return
+	}
+
+	message := "user unbanned"
+	if banned {
+		message = "user banned"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+	})
+}
+
+// ShadowBanUser handles POST /admin/users/:id/shadow-ban
+// @Summary Shadow-ban user
+// @Description Hide a user from feeds and likes of everyone else without telling them
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body admin.ModerationRequest false "Reason"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/shadow-ban [post]
+func (h *AdminHandler) ShadowBanUser(c *gin.Context) {
+	h.setShadowBanned(c, true)
+}
+
+// UnshadowBanUser handles POST /admin/users/:id/shadow-unban
+// @Summary Lift shadow ban
+// @Description Make a shadow-banned user visible again
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body admin.ModerationRequest false "Reason"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/shadow-unban [post]
+func (h *AdminHandler) UnshadowBanUser(c *gin.Context) {
+	h.setShadowBanned(c, false)
+}
+
+func (h *AdminHandler) setShadowBanned(c *gin.Context, shadowBanned bool) {
+	actor, userID, req, ok := moderationInput(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUseCase.SetShadowBanned(c.Request.Context(), actor, userID, shadowBanned, req); err != nil {
+		respondAdminError(c, err, "failed to update shadow ban")
+		return
+	}
+
+	message := "shadow ban lifted"
+	if shadowBanned {
+		message = "user shadow-banned"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+	})
+}
+
+// ExpireSessions handles POST /admin/users/:id/expire-sessions
+// @Summary Expire user sessions
+// @Description Log a user out of all devices (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/expire-sessions [post]
+func (h *AdminHandler) ExpireSessions(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := pathID(c, "id", "invalid user id")
+	if !ok {
+		return
+	}
+
+	if err := h.adminUseCase.ExpireSessions(c.Request.Context(), actor, userID); err != nil {
+		respondAdminError(c, err, "failed to expire sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "sessions expired",
+	})
+}
+
+// ListReports handles GET /admin/reports
+// @Summary List reports
+// @Description Get the moderation queue, oldest first
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Report status" Enums(pending, resolved, dismissed)
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} domain.Report
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/reports [get]
+func (h *AdminHandler) ListReports(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	var status *domain.ReportStatus
+	if statusStr := c.Query("status"); statusStr != "" {
+		s := domain.ReportStatus(statusStr)
+		switch s {
+		case domain.ReportStatusPending, domain.ReportStatusResolved, domain.ReportStatusDismissed:
+			status = &s
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "invalid status",
+			})
+			return
+		}
+	}
+
+	limit, offset := adminPage(c)
+	reports, err := h.adminUseCase.ListReports(c.Request.Context(), actor, status, limit, offset)
+	if err != nil {
+		respondAdminError(c, err, "failed to list reports")
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+// ResolveReport handles POST /admin/reports/:id/resolve
+// @Summary Resolve report
+// @Description Close a pending report as resolved or dismissed
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body admin.ResolveReportRequest true "Resolution"
+// @Success 200 {object} domain.Report
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/reports/{id}/resolve [post]
+func (h *AdminHandler) ResolveReport(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	reportID, ok := pathID(c, "id", "invalid report id")
+	if !ok {
+		return
+	}
+
+	var req admin.ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+		})
+		return
+	}
+
+	report, err := h.adminUseCase.ResolveReport(c.Request.Context(), actor, reportID, &req)
+	if err != nil {
+		respondAdminError(c, err, "failed to resolve report")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ListAuditLog handles GET /admin/audit-log
+// @Summary List audit log
+// @Description Get admin and moderator actions, newest first (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param actor_id query int false "Actor user ID"
+// @Param target_type query string false "Target type" Enums(user, report, audit_log)
+// @Param target_id query int false "Target ID"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} domain.AuditEntry
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/audit-log [get]
+func (h *AdminHandler) ListAuditLog(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	var filter repository.AuditFilter
+	filter.Limit, filter.Offset = adminPage(c)
+	filter.TargetType = c.Query("target_type")
+
+	for param, dst := range map[string]**int{
+		"actor_id":  &filter.ActorID,
+		"target_id": &filter.TargetID,
+	} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "invalid " + param,
+			})
+			return
+		}
+		*dst = &id
+	}
+
+	entries, err := h.adminUseCase.ListAuditLog(c.Request.Context(), actor, filter)
+	if err != nil {
+		respondAdminError(c, err, "failed to list audit log")
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// adminActor reads the authenticated admin or moderator from the context
+func adminActor(c *gin.Context) (admin.Actor, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return admin.Actor{}, false
+	}
+
+	role, _ := c.Get("user_role")
+	actorRole, _ := role.(domain.UserRole)
+
+	return admin.Actor{
+		ID:   userID.(int),
+		Role: actorRole,
+		IP:   c.ClientIP(),
+	}, true
+}
+
+func moderationInput(c *gin.Context) (admin.Actor, int, *admin.ModerationRequest, bool) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return actor, 0, nil, false
+	}
+
+	userID, ok := pathID(c, "id", "invalid user id")
+	if !ok {
+		return actor, 0, nil, false
+	}
+
+	// The reason is optional, so an empty body is fine
+	var req admin.ModerationRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "invalid request body",
+			})
+			return actor, 0, nil, false
+		}
+	}
+
+	return actor, userID, &req, true
+}
+
+func pathID(c *gin.Context, param, message string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: message,
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+func adminPage(c *gin.Context) (int, int) {
+	limit := 50
+	offset := 0
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 200 {
+			limit = l
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	return limit, offset
+}
+
+func respondAdminError(c *gin.Context, err error, fallback string) {
+	statusCode := http.StatusInternalServerError
+	message := fallback
+
+	switch err {
+	case domain.ErrUserNotFound:
+		statusCode = http.StatusNotFound
+		message = "user not found"
+	case domain.ErrReportNotFound:
+		statusCode = http.StatusNotFound
+		message = "report not found"
+	case domain.ErrReportResolved:
+		statusCode = http.StatusConflict
+		message = "report is already resolved"
+	case domain.ErrForbidden:
+		statusCode = http.StatusForbidden
+		message = "cannot ban yourself"
+	case domain.ErrTargetOutranks:
+		statusCode = http.StatusForbidden
+		message = "target has an equal or higher role"
+	}
+
+	c.JSON(statusCode, ErrorResponse{
+		Error: message,
+	})
+}
@@ -3,7 +3,9 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
 	"github.com/gdugdh24/mpit2026-backend/internal/usecase/auth"
 	"github.com/gin-gonic/gin"
 )
@@ -26,10 +28,25 @@ type VKAuthRequest struct {
 
 // AuthResponse is the response structure
 type AuthResponse struct {
-	Token     string      `json:"token"`
-	ExpiresAt int64       `json:"expires_at"`
-	User      interface{} `json:"user"`
-	IsNewUser bool        `json:"is_new_user"`
+	Token            string      `json:"token"`
+	ExpiresAt        int64       `json:"expires_at"`
+	RefreshToken     string      `json:"refresh_token"`
+	RefreshExpiresAt int64       `json:"refresh_expires_at"`
+	User             interface{} `json:"user"`
+	IsNewUser        bool        `json:"is_new_user"`
+}
+
+// RefreshRequest represents token refresh request
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse represents a rotated token pair
+type TokenResponse struct {
+	Token            string `json:"token"`
+	ExpiresAt        int64  `json:"expires_at"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt int64  `json:"refresh_expires_at"`
 }
 
 // VKAuth handles VK Mini App authentication
@@ -42,6 +59,7 @@ type AuthResponse struct {
 // @Success 200 {object} AuthResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /auth/vk [post]
 func (h *AuthHandler) VKAuth(c *gin.Context) {
@@ -53,9 +71,6 @@ func (h *AuthHandler) VKAuth(c *gin.Context) {
 		return
 	}
 
-	// Debug logging
-	c.Writer.Header().Set("X-Debug-VK-Params", fmt.Sprintf("%+v", req.VKParams))
-
 	deviceInfo := c.GetHeader("User-Agent")
 	ipAddress := c.ClientIP()
 
@@ -71,6 +86,9 @@ func (h *AuthHandler) VKAuth(c *gin.Context) {
 		case "invalid input":
 			statusCode = http.StatusBadRequest
 			message = "invalid VK parameters"
+		case "user is banned":
+			statusCode = http.StatusForbidden
+			message = "user is banned"
 		}
 
 		c.JSON(statusCode, ErrorResponse{
@@ -80,10 +98,12 @@ func (h *AuthHandler) VKAuth(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, AuthResponse{
-		Token:     result.Token,
-		ExpiresAt: result.ExpiresAt.Unix(),
-		User:      result.User,
-		IsNewUser: result.IsNewUser,
+		Token:            result.AccessToken,
+		ExpiresAt:        result.ExpiresAt.Unix(),
+		RefreshToken:     result.RefreshToken,
+		RefreshExpiresAt: result.RefreshExpiresAt.Unix(),
+		User:             result.User,
+		IsNewUser:        result.IsNewUser,
 	})
 }
 
@@ -92,19 +112,21 @@ type TestAuthRequest struct {
 	VKID      int    `json:"vk_id" binding:"required"`
 	FirstName string `json:"first_name" binding:"required"`
 	LastName  string `json:"last_name" binding:"required"`
-	Gender    string `json:"gender" binding:"required,oneof=male female"`
+	Gender    string `json:"gender" binding:"required,oneof=male female non_binary"`
 	BirthDate string `json:"birth_date" binding:"required"` // Format: YYYY-MM-DD
 }
 
 // TestAuth creates a test user without VK signature validation (for development/testing only)
 // @Summary Test authentication
-// @Description Create test user without VK validation (dev only)
+// @Description Create synthetic test user without VK validation. Mounted only when ENV is development or test.
 // @Tags auth
 // @Accept json
 // @Produce json
+// @Param X-Dev-Secret header string true "Shared dev secret (DEV_AUTH_SECRET)"
 // @Param request body TestAuthRequest true "Test user data"
 // @Success 200 {object} AuthResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /auth/test [post]
 func (h *AuthHandler) TestAuth(c *gin.Context) {
@@ -133,6 +155,12 @@ func (h *AuthHandler) TestAuth(c *gin.Context) {
 	ipAddress := c.ClientIP()
 
 	result, err := h.authUseCase.AuthenticateVKTest(c.Request.Context(), vkParams, deviceInfo, ipAddress)
+	if err == domain.ErrUserBanned {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "user is banned",
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: fmt.Sprintf("test auth failed: %v", err),
@@ -141,10 +169,71 @@ func (h *AuthHandler) TestAuth(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, AuthResponse{
-		Token:     result.Token,
-		ExpiresAt: result.ExpiresAt.Unix(),
-		User:      result.User,
-		IsNewUser: result.IsNewUser,
+		Token:            result.AccessToken,
+		ExpiresAt:        result.ExpiresAt.Unix(),
+		RefreshToken:     result.RefreshToken,
+		RefreshExpiresAt: result.RefreshExpiresAt.Unix(),
+		User:             result.User,
+		IsNewUser:        result.IsNewUser,
+	})
+}
+
+// Refresh rotates refresh token and issues a new token pair
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access/refresh token pair. Reusing an old refresh token revokes the whole session family.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body RefreshRequest true "Refresh token"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+		})
+		return
+	}
+
+	deviceInfo := c.GetHeader("User-Agent")
+	ipAddress := c.ClientIP()
+
+	result, err := h.authUseCase.Refresh(c.Request.Context(), req.RefreshToken, deviceInfo, ipAddress)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		message := "failed to refresh token"
+
+		switch err {
+		case domain.ErrInvalidToken, domain.ErrSessionNotFound:
+			statusCode = http.StatusUnauthorized
+			message = "invalid refresh token"
+		case domain.ErrSessionExpired:
+			statusCode = http.StatusUnauthorized
+			message = "refresh token expired"
+		case domain.ErrRefreshTokenReused:
+			statusCode = http.StatusUnauthorized
+			message = "refresh token reused, all sessions of this login were revoked"
+		case domain.ErrUserBanned:
+			statusCode = http.StatusForbidden
+			message = "user is banned"
+		}
+
+		c.JSON(statusCode, ErrorResponse{
+			Error: message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, TokenResponse{
+		Token:            result.AccessToken,
+		ExpiresAt:        result.ExpiresAt.Unix(),
+		RefreshToken:     result.RefreshToken,
+		RefreshExpiresAt: result.RefreshExpiresAt.Unix(),
 	})
 }
 
@@ -184,6 +273,124 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	})
 }
 
+// ListSessions returns user's active sessions
+// @Summary List sessions
+// @Description List active sessions (devices) with parsed platform and app version; the current one is marked
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	sessions, err := h.authUseCase.ListSessions(c.Request.Context(), userID.(int), c.GetString("token"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to get sessions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sessions": sessions,
+	})
+}
+
+// RevokeSession revokes one of user's sessions
+// @Summary Revoke session
+// @Description Log out a single device
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Session ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid session id",
+		})
+		return
+	}
+
+	if err := h.authUseCase.RevokeSession(c.Request.Context(), userID.(int), sessionID); err != nil {
+		statusCode := http.StatusInternalServerError
+		message := "failed to revoke session"
+
+		switch err {
+		case domain.ErrSessionNotFound:
+			statusCode = http.StatusNotFound
+			message = "session not found"
+		case domain.ErrForbidden:
+			statusCode = http.StatusForbidden
+			message = "access to this session is forbidden"
+		}
+
+		c.JSON(statusCode, ErrorResponse{
+			Error: message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "session revoked",
+	})
+}
+
+// LogoutAll logs user out on every device
+// @Summary Logout everywhere
+// @Description Revoke all sessions of the user, including the current one
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	if err := h.authUseCase.LogoutAll(c.Request.Context(), userID.(int)); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "logout failed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "logged out on all devices",
+	})
+}
+
 // Me returns current user info
 // @Summary Get current user
 // @Description Get authenticated user information
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/usecase/feed"
 	"github.com/gin-gonic/gin"
@@ -17,6 +18,44 @@ func NewFeedHandler(feedUseCase *feed.FeedUseCase) *FeedHandler {
 	}
 }
 
+// GetFeed handles GET /feed
+// @Summary Get feed deck
+// @Description Get the next ranked cards with compatibility score breakdown. The ranking is cached per user; swiping a card advances the deck.
+// @Tags feed
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Number of cards" default(10)
+// @Success 200 {object} feed.FeedPage
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /feed [get]
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	page, err := h.feedUseCase.GetFeed(c.Request.Context(), userID.(int), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to get feed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
 // GetNextUser handles GET /feed/next
 // @Summary Get next user in feed
 // @Description Get the next user to show in feed based on preferences
@@ -59,12 +98,14 @@ func (h *FeedHandler) GetNextUser(c *gin.Context) {
 }
 
 // ResetDislikes handles POST /feed/reset-dislikes
-// @Summary Reset all dislikes
-// @Description Delete all dislikes to refresh the feed
+// @Summary Reset dislikes
+// @Description Delete dislikes (optionally only older than N days) to refresh the feed
 // @Tags feed
 // @Security BearerAuth
 // @Produce json
+// @Param older_than_days query int false "Only reset dislikes older than this many days"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /feed/reset-dislikes [post]
@@ -77,7 +118,19 @@ func (h *FeedHandler) ResetDislikes(c *gin.Context) {
 		return
 	}
 
-	count, err := h.feedUseCase.ResetDislikes(c.Request.Context(), userID.(int))
+	olderThanDays := 0
+	if daysStr := c.Query("older_than_days"); daysStr != "" {
+		d, err := strconv.Atoi(daysStr)
+		if err != nil || d < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "invalid older_than_days",
+			})
+			return
+		}
+		olderThanDays = d
+	}
+
+	count, err := h.feedUseCase.ResetDislikes(c.Request.Context(), userID.(int), olderThanDays)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: "failed to reset dislikes",
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/health"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	checker *health.Checker
+	log     *slog.Logger
+}
+
+func NewHealthHandler(checker *health.Checker, log *slog.Logger) *HealthHandler {
+	return &HealthHandler{
+		checker: checker,
+		log:     log,
+	}
+}
+
+// Livez handles GET /livez
+// @Summary Liveness probe
+// @Description Reports that the process is up and serving HTTP, dependencies are not checked
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ok",
+	})
+}
+
+// Readyz handles GET /readyz
+// @Summary Readiness probe
+// @Description Pings Postgres, Redis (when enabled) and the ML service (when configured) and reports each dependency's status
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	report := h.checker.Check(c.Request.Context())
+
+	statusCode := http.StatusOK
+	if !report.Ready {
+		statusCode = http.StatusServiceUnavailable
+		for name, dep := range report.Dependencies {
+			if dep.Status == health.StatusDown {
+				h.log.WarnContext(c.Request.Context(), "dependency is not ready", "dependency", name, "error", dep.Error)
+			}
+		}
+	}
+
+	c.JSON(statusCode, report)
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/interest"
+	"github.com/gin-gonic/gin"
+)
+
+type InterestHandler struct {
+	interestUseCase *interest.InterestUseCase
+}
+
+func NewInterestHandler(interestUseCase *interest.InterestUseCase) *InterestHandler {
+	return &InterestHandler{
+		interestUseCase: interestUseCase,
+	}
+}
+
+// SearchInterests handles GET /interests
+// @Summary Search interests
+// @Description Autocomplete over the interest catalog by Russian or English name and synonyms. Without q the whole catalog is returned. Profiles store the returned slugs.
+// @Tags interests
+// @Produce json
+// @Param q query string false "Search text"
+// @Param limit query int false "Max results when q is set (default 20)"
+// @Success 200 {array} domain.Interest
+// @Failure 500 {object} ErrorResponse
+// @Router /interests [get]
+func (h *InterestHandler) SearchInterests(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	interests, err := h.interestUseCase.Search(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to get interests",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, interests)
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/match"
+	"github.com/gin-gonic/gin"
+)
+
+type MatchHandler struct {
+	matchUseCase *match.MatchUseCase
+}
+
+func NewMatchHandler(matchUseCase *match.MatchUseCase) *MatchHandler {
+	return &MatchHandler{
+		matchUseCase: matchUseCase,
+	}
+}
+
+// GetMatches handles GET /matches
+// @Summary Get matches
+// @Description Get active matches with the other user's profile and last message
+// @Tags matches
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /matches [get]
+func (h *MatchHandler) GetMatches(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	// Parse query params
+	limit := 20
+	offset := 0
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	matches, total, err := h.matchUseCase.GetMatches(c.Request.Context(), userID.(int), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to get matches",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"matches": matches,
+		"total":   total,
+	})
+}
+
+// GetMatch handles GET /matches/:id
+// @Summary Get match
+// @Description Get a single match with the other user's profile and last message
+// @Tags matches
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Match ID"
+// @Success 200 {object} match.MatchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /matches/{id} [get]
+func (h *MatchHandler) GetMatch(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid match id",
+		})
+		return
+	}
+
+	result, err := h.matchUseCase.GetMatch(c.Request.Context(), userID.(int), matchID)
+	if err != nil {
+		h.handleMatchError(c, err, "failed to get match")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Unmatch handles DELETE /matches/:id
+// @Summary Unmatch
+// @Description Deactivate a match so neither side can message again
+// @Tags matches
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Match ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /matches/{id} [delete]
+func (h *MatchHandler) Unmatch(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid match id",
+		})
+		return
+	}
+
+	if err := h.matchUseCase.Unmatch(c.Request.Context(), userID.(int), matchID); err != nil {
+		h.handleMatchError(c, err, "failed to unmatch")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "unmatched successfully",
+	})
+}
+
+// handleMatchError maps match domain errors to HTTP responses
+func (h *MatchHandler) handleMatchError(c *gin.Context, err error, fallback string) {
+	statusCode := http.StatusInternalServerError
+	message := fallback
+
+	switch err {
+	case domain.ErrMatchNotFound:
+		statusCode = http.StatusNotFound
+		message = "match not found"
+	case domain.ErrForbidden:
+		statusCode = http.StatusForbidden
+		message = "access to this match is forbidden"
+	}
+
+	c.JSON(statusCode, ErrorResponse{
+		Error: message,
+	})
+}
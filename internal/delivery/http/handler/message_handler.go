@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/message"
+	"github.com/gin-gonic/gin"
+)
+
+type MessageHandler struct {
+	messageUseCase *message.MessageUseCase
+}
+
+func NewMessageHandler(messageUseCase *message.MessageUseCase) *MessageHandler {
+	return &MessageHandler{
+		messageUseCase: messageUseCase,
+	}
+}
+
+// GetMessages handles GET /matches/:id/messages
+// @Summary Get chat messages
+// @Description Get decrypted messages of a match with cursor pagination
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Match ID"
+// @Param before query int false "Return messages older than this message ID"
+// @Param after query int false "Return messages newer than this message ID"
+// @Param limit query int false "Limit" default(50)
+// @Success 200 {object} message.MessagesPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /matches/{id}/messages [get]
+func (h *MessageHandler) GetMessages(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid match id",
+		})
+		return
+	}
+
+	// Parse cursor params
+	var q message.MessagesQuery
+	if beforeStr := c.Query("before"); beforeStr != "" {
+		if b, err := strconv.Atoi(beforeStr); err == nil && b > 0 {
+			q.Before = b
+		}
+	}
+	if afterStr := c.Query("after"); afterStr != "" {
+		if a, err := strconv.Atoi(afterStr); err == nil && a > 0 {
+			q.After = a
+		}
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			q.Limit = l
+		}
+	}
+
+	page, err := h.messageUseCase.GetMessages(c.Request.Context(), userID.(int), matchID, q)
+	if err != nil {
+		h.handleMessageError(c, err, "failed to get messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// SendMessage handles POST /matches/:id/messages
+// @Summary Send message
+// @Description Send an encrypted message to an active match
+// @Tags messages
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Match ID"
+// @Param request body message.SendMessageRequest true "Message"
+// @Success 201 {object} domain.Message
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /matches/{id}/messages [post]
+func (h *MessageHandler) SendMessage(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid match id",
+		})
+		return
+	}
+
+	var req message.SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+		})
+		return
+	}
+
+	msg, err := h.messageUseCase.SendMessage(c.Request.Context(), userID.(int), matchID, &req)
+	if err != nil {
+		h.handleMessageError(c, err, "failed to send message")
+		return
+	}
+
+	c.JSON(http.StatusCreated, msg)
+}
+
+// MarkAsRead handles POST /matches/:id/messages/:message_id/read
+// @Summary Mark message as read
+// @Description Send a read receipt for a single incoming message
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Match ID"
+// @Param message_id path int true "Message ID"
+// @Success 200 {object} message.ReadReceipt
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /matches/{id}/messages/{message_id}/read [post]
+func (h *MessageHandler) MarkAsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid match id",
+		})
+		return
+	}
+
+	messageID, err := strconv.Atoi(c.Param("message_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid message id",
+		})
+		return
+	}
+
+	receipt, err := h.messageUseCase.MarkAsRead(c.Request.Context(), userID.(int), matchID, messageID)
+	if err != nil {
+		h.handleMessageError(c, err, "failed to mark message as read")
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+// MarkAllAsRead handles POST /matches/:id/messages/read-all
+// @Summary Mark all messages as read
+// @Description Send a read receipt for all incoming messages in a match
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Match ID"
+// @Success 200 {object} message.ReadReceipt
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /matches/{id}/messages/read-all [post]
+func (h *MessageHandler) MarkAllAsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid match id",
+		})
+		return
+	}
+
+	receipt, err := h.messageUseCase.MarkAllAsRead(c.Request.Context(), userID.(int), matchID)
+	if err != nil {
+		h.handleMessageError(c, err, "failed to mark messages as read")
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+// handleMessageError maps chat domain errors to HTTP responses
+func (h *MessageHandler) handleMessageError(c *gin.Context, err error, fallback string) {
+	statusCode := http.StatusInternalServerError
+	message := fallback
+
+	switch err {
+	case domain.ErrMatchNotFound:
+		statusCode = http.StatusNotFound
+		message = "match not found"
+	case domain.ErrMessageNotFound:
+		statusCode = http.StatusNotFound
+		message = "message not found"
+	case domain.ErrForbidden:
+		statusCode = http.StatusForbidden
+		message = "access to this match is forbidden"
+	case domain.ErrNotMatched:
+		statusCode = http.StatusForbidden
+		message = "users are not matched"
+	case domain.ErrUnauthorizedMessage:
+		statusCode = http.StatusForbidden
+		message = "unauthorized to access message"
+	case domain.ErrUserBanned:
+		statusCode = http.StatusForbidden
+		message = "user is banned"
+	}
+
+	c.JSON(statusCode, ErrorResponse{
+		Error: message,
+	})
+}
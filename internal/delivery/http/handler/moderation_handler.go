@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/moderation"
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationHandler struct {
+	moderationUseCase *moderation.ModerationUseCase
+}
+
+func NewModerationHandler(moderationUseCase *moderation.ModerationUseCase) *ModerationHandler {
+	return &ModerationHandler{
+		moderationUseCase: moderationUseCase,
+	}
+}
+
+// BlockUser handles POST /users/:id/block
+// @Summary Block a user
+// @Description Hide both users from each other's feed, likes and profiles and deactivate their match
+// @Tags moderation
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} domain.Block
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/{id}/block [post]
+func (h *ModerationHandler) BlockUser(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	targetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid user id",
+		})
+		return
+	}
+
+	block, err := h.moderationUseCase.BlockUser(c.Request.Context(), userID.(int), targetID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		message := "failed to block user"
+
+		switch err {
+		case domain.ErrCannotBlockSelf:
+			statusCode = http.StatusBadRequest
+			message = "cannot block yourself"
+		case domain.ErrUserNotFound:
+			statusCode = http.StatusNotFound
+			message = "user not found"
+		}
+
+		c.JSON(statusCode, ErrorResponse{
+			Error: message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, block)
+}
+
+// ReportUser handles POST /users/:id/report
+// @Summary Report a user
+// @Description Send a report to the moderation queue, optionally with a message as evidence
+// @Tags moderation
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body moderation.ReportRequest true "Report"
+// @Success 201 {object} domain.Report
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/{id}/report [post]
+func (h *ModerationHandler) ReportUser(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	targetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid user id",
+		})
+		return
+	}
+
+	var req moderation.ReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+		})
+		return
+	}
+
+	report, err := h.moderationUseCase.ReportUser(c.Request.Context(), userID.(int), targetID, &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		message := "failed to report user"
+
+		switch err {
+		case domain.ErrCannotReportSelf:
+			statusCode = http.StatusBadRequest
+			message = "cannot report yourself"
+		case domain.ErrUserNotFound:
+			statusCode = http.StatusNotFound
+			message = "user not found"
+		case domain.ErrMessageNotFound, domain.ErrMatchNotFound:
+			statusCode = http.StatusBadRequest
+			message = "message not found in your chat with this user"
+		}
+
+		c.JSON(statusCode, ErrorResponse{
+			Error: message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
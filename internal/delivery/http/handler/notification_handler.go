@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/notification"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationUseCase *notification.NotificationUseCase
+}
+
+func NewNotificationHandler(notificationUseCase *notification.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// GetNotifications handles GET /notifications
+// @Summary Get notifications
+// @Description Get the user's notifications, newest first, with the unread counter
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} notification.NotificationsPage
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	// Parse query params
+	limit := 20
+	offset := 0
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	page, err := h.notificationUseCase.GetNotifications(c.Request.Context(), userID.(int), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to get notifications",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// GetUnreadCount handles GET /notifications/unread-count
+// @Summary Get unread notifications count
+// @Description Get the unread notifications badge counter
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]int
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	count, err := h.notificationUseCase.GetUnreadCount(c.Request.Context(), userID.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to get unread count",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unread_count": count,
+	})
+}
+
+// MarkAsRead handles POST /notifications/:id/read
+// @Summary Mark notification as read
+// @Description Mark a single notification as read
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	notificationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid notification id",
+		})
+		return
+	}
+
+	if err := h.notificationUseCase.MarkAsRead(c.Request.Context(), userID.(int), notificationID); err != nil {
+		statusCode := http.StatusInternalServerError
+		message := "failed to mark notification as read"
+
+		switch err {
+		case domain.ErrNotificationNotFound:
+			statusCode = http.StatusNotFound
+			message = "notification not found"
+		case domain.ErrForbidden:
+			statusCode = http.StatusForbidden
+			message = "access to this notification is forbidden"
+		}
+
+		c.JSON(statusCode, ErrorResponse{
+			Error: message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "notification marked as read",
+	})
+}
+
+// MarkAllAsRead handles POST /notifications/read-all
+// @Summary Mark all notifications as read
+// @Description Mark all of the user's notifications as read
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	if err := h.notificationUseCase.MarkAllAsRead(c.Request.Context(), userID.(int)); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to mark notifications as read",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "all notifications marked as read",
+	})
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/photo"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead leaves room for multipart headers and boundaries on top of the file itself
+const multipartOverhead = 64 << 10
+
+type PhotoHandler struct {
+	photoUseCase   *photo.PhotoUseCase
+	maxUploadBytes int64
+}
+
+func NewPhotoHandler(photoUseCase *photo.PhotoUseCase, maxUploadBytes int64) *PhotoHandler {
+	return &PhotoHandler{
+		photoUseCase:   photoUseCase,
+		maxUploadBytes: maxUploadBytes,
+	}
+}
+
+// ListPhotos handles GET /profile/photos
+// @Summary List my photos
+// @Description Get current user's photos in display order
+// @Tags photos
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} domain.Photo
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /profile/photos [get]
+func (h *PhotoHandler) ListPhotos(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	photos, err := h.photoUseCase.ListPhotos(c.Request.Context(), userID.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to get photos",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, photos)
+}
+
+// UploadPhoto handles POST /profile/photos
+// @Summary Upload photo
+// @Description Upload a JPEG, PNG or WebP photo. The type is detected from the contents, metadata (EXIF, GPS) is stripped and a thumbnail is generated.
+// @Tags photos
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Photo"
+// @Success 201 {object} domain.Photo
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /profile/photos [post]
+func (h *PhotoHandler) UploadPhoto(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	tooLarge := ErrorResponse{
+		Error: fmt.Sprintf("photo is larger than %d MB", h.maxUploadBytes>>20),
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+			return
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "file is required",
+		})
+		return
+	}
+	if fileHeader.Size > h.maxUploadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "failed to read file",
+		})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, h.maxUploadBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "failed to read file",
+		})
+		return
+	}
+	if int64(len(data)) > h.maxUploadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+		return
+	}
+
+	photo, err := h.photoUseCase.Upload(c.Request.Context(), userID.(int), data)
+	if err != nil {
+		h.respondError(c, err, "failed to upload photo")
+		return
+	}
+
+	c.JSON(http.StatusCreated, photo)
+}
+
+// DeletePhoto handles DELETE /profile/photos/:id
+// @Summary Delete photo
+// @Description Delete a photo, the next one becomes primary if the primary photo is deleted
+// @Tags photos
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Photo ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /profile/photos/{id} [delete]
+func (h *PhotoHandler) DeletePhoto(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	photoID, ok := pathID(c, "id", "invalid photo id")
+	if !ok {
+		return
+	}
+
+	if err := h.photoUseCase.DeletePhoto(c.Request.Context(), userID.(int), photoID); err != nil {
+		h.respondError(c, err, "failed to delete photo")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "photo deleted",
+	})
+}
+
+// SetPrimary handles PUT /profile/photos/:id/primary
+// @Summary Set primary photo
+// @Description Make the photo the one shown first on cards
+// @Tags photos
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Photo ID"
+// @Success 200 {array} domain.Photo
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /profile/photos/{id}/primary [put]
+func (h *PhotoHandler) SetPrimary(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	photoID, ok := pathID(c, "id", "invalid photo id")
+	if !ok {
+		return
+	}
+
+	photos, err := h.photoUseCase.SetPrimary(c.Request.Context(), userID.(int), photoID)
+	if err != nil {
+		h.respondError(c, err, "failed to set primary photo")
+		return
+	}
+
+	c.JSON(http.StatusOK, photos)
+}
+
+// MovePhoto handles PUT /profile/photos/:id/order
+// @Summary Reorder photo
+// @Description Move the photo to a position, the photos in between shift by one
+// @Tags photos
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Photo ID"
+// @Param request body photo.MovePhotoRequest true "New position"
+// @Success 200 {array} domain.Photo
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /profile/photos/{id}/order [put]
+func (h *PhotoHandler) MovePhoto(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	photoID, ok := pathID(c, "id", "invalid photo id")
+	if !ok {
+		return
+	}
+
+	var req photo.MovePhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+		})
+		return
+	}
+
+	photos, err := h.photoUseCase.MovePhoto(c.Request.Context(), userID.(int), photoID, &req)
+	if err != nil {
+		h.respondError(c, err, "failed to move photo")
+		return
+	}
+
+	c.JSON(http.StatusOK, photos)
+}
+
+func (h *PhotoHandler) respondError(c *gin.Context, err error, fallback string) {
+	statusCode := http.StatusInternalServerError
+	message := fallback
+
+	switch err {
+	case domain.ErrPhotoNotFound:
+		statusCode = http.StatusNotFound
+		message = "photo not found"
+	case domain.ErrPhotoLimitReached:
+		statusCode = http.StatusConflict
+		message = fmt.Sprintf("at most %d photos allowed", domain.MaxPhotosPerUser)
+	case domain.ErrUnsupportedImage:
+		statusCode = http.StatusUnsupportedMediaType
+		message = "only JPEG, PNG and WebP images are supported"
+	case domain.ErrImageTooLarge:
+		statusCode = http.StatusRequestEntityTooLarge
+		message = "image dimensions are too large"
+	}
+
+	c.JSON(statusCode, ErrorResponse{
+		Error: message,
+	})
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -12,11 +14,13 @@ import (
 
 type ProfileHandler struct {
 	profileUseCase *profile.ProfileUseCase
+	log            *slog.Logger
 }
 
-func NewProfileHandler(profileUseCase *profile.ProfileUseCase) *ProfileHandler {
+func NewProfileHandler(profileUseCase *profile.ProfileUseCase, log *slog.Logger) *ProfileHandler {
 	return &ProfileHandler{
 		profileUseCase: profileUseCase,
+		log:            log,
 	}
 }
 
@@ -59,7 +63,7 @@ func (h *ProfileHandler) GetMyProfile(c *gin.Context) {
 
 // UpdateMyProfile handles PUT /profile/me
 // @Summary Update my profile
-// @Description Update current user's profile
+// @Description Update current user's profile. Interests are resolved to catalog slugs (see GET /interests), unknown interests are rejected.
 // @Tags profile
 // @Security BearerAuth
 // @Accept json
@@ -89,6 +93,9 @@ func (h *ProfileHandler) UpdateMyProfile(c *gin.Context) {
 
 	updatedProfile, err := h.profileUseCase.UpdateProfile(c.Request.Context(), userID.(int), &req)
 	if err != nil {
+		if respondUnknownInterest(c, err) {
+			return
+		}
 		if err == domain.ErrProfileNotFound {
 			c.JSON(http.StatusNotFound, ErrorResponse{
 				Error: "profile not found",
@@ -106,7 +113,7 @@ func (h *ProfileHandler) UpdateMyProfile(c *gin.Context) {
 
 // CompleteOnboarding handles POST /profile/complete-onboarding
 // @Summary Complete onboarding
-// @Description Create profile and complete onboarding
+// @Description Create profile and complete onboarding. Interests are resolved to catalog slugs (see GET /interests), unknown interests are rejected.
 // @Tags profile
 // @Security BearerAuth
 // @Accept json
@@ -137,6 +144,9 @@ func (h *ProfileHandler) CompleteOnboarding(c *gin.Context) {
 
 	newProfile, err := h.profileUseCase.CreateProfile(c.Request.Context(), userID.(int), &req)
 	if err != nil {
+		if respondUnknownInterest(c, err) {
+			return
+		}
 		if err == domain.ErrProfileAlreadyExists {
 			c.JSON(http.StatusConflict, ErrorResponse{
 				Error: "profile already exists",
@@ -203,13 +213,13 @@ func (h *ProfileHandler) GetProfileByUserID(c *gin.Context) {
 
 // GenerateBio handles POST /profile/generate-bio
 // @Summary Generate bio with AI
-// @Description Generate 3 creative bios
+// @Description Generate 3 creative bios. fallback is true when the AI failed and the bios come from templates.
 // @Tags profile
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param request body profile.GenerateBioRequest true "Bio generation data"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} profile.GenerateBioResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -231,14 +241,76 @@ func (h *ProfileHandler) GenerateBio(c *gin.Context) {
 		return
 	}
 
-	bios, err := h.profileUseCase.GenerateBio(c.Request.Context(), &req)
+	resp, err := h.profileUseCase.GenerateBio(c.Request.Context(), &req)
 	if err != nil {
-		fmt.Printf("Error generating bio: %v\n", err)
+		h.log.ErrorContext(c.Request.Context(), "failed to generate bio", "error", err)
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: "failed to generate bio",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, bios)
+	c.JSON(http.StatusOK, resp)
+}
+
+// ImportVK handles POST /profile/import-vk
+// @Summary Import profile from VK
+// @Description Fill the profile with VK name and city and import the VK avatar as the primary photo. Only missing data is filled in, so it is safe to call again.
+// @Tags profile
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} profile.VKImportResult
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /profile/import-vk [post]
+func (h *ProfileHandler) ImportVK(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	result, err := h.profileUseCase.ImportFromVK(c.Request.Context(), userID.(int))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		message := "failed to import VK profile"
+
+		switch err {
+		case domain.ErrUserNotFound:
+			statusCode = http.StatusNotFound
+			message = "user not found"
+		case domain.ErrVKTokenExpired:
+			statusCode = http.StatusConflict
+			message = "VK token expired, log in again"
+		case domain.ErrPhotoLimitReached:
+			statusCode = http.StatusConflict
+			message = fmt.Sprintf("at most %d photos allowed, VK avatar not imported", domain.MaxPhotosPerUser)
+		default:
+			h.log.ErrorContext(c.Request.Context(), "failed to import VK profile", "user_id", userID, "error", err)
+		}
+
+		c.JSON(statusCode, ErrorResponse{
+			Error: message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// respondUnknownInterest writes 400 with the rejected interests if err is an
+// UnknownInterestError and reports whether it did
+func respondUnknownInterest(c *gin.Context, err error) bool {
+	var unknownErr *domain.UnknownInterestError
+	if !errors.As(err, &unknownErr) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error: unknownErr.Error(),
+	})
+	return true
 }
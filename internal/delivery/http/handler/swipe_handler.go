@@ -1,14 +1,24 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/domain"
 	"github.com/gdugdh24/mpit2026-backend/internal/usecase/swipe"
 	"github.com/gin-gonic/gin"
 )
 
+// QuotaErrorResponse is returned with 429 when a daily swipe limit is reached
+type QuotaErrorResponse struct {
+	Error     string    `json:"error"`
+	Direction string    `json:"direction"`
+	Limit     int       `json:"limit"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
 type SwipeHandler struct {
 	swipeUseCase *swipe.SwipeUseCase
 }
@@ -20,8 +30,8 @@ func NewSwipeHandler(swipeUseCase *swipe.SwipeUseCase) *SwipeHandler {
 }
 
 // CreateSwipe handles POST /swipe
-// @Summary Create a swipe (like/dislike)
-// @Description Swipe on a user and check if it's a match
+// @Summary Create a swipe (left/right/super)
+// @Description Swipe on a user and check if it's a match. Likes and super-likes are limited per day
 // @Tags swipe
 // @Security BearerAuth
 // @Accept json
@@ -30,7 +40,10 @@ func NewSwipeHandler(swipeUseCase *swipe.SwipeUseCase) *SwipeHandler {
 // @Success 200 {object} swipe.SwipeResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
+// @Failure 429 {object} QuotaErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /swipe [post]
 func (h *SwipeHandler) CreateSwipe(c *gin.Context) {
@@ -52,6 +65,19 @@ func (h *SwipeHandler) CreateSwipe(c *gin.Context) {
 
 	result, err := h.swipeUseCase.CreateSwipe(c.Request.Context(), userID.(int), &req)
 	if err != nil {
+		var quotaErr *domain.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			retryAfter := int(time.Until(quotaErr.ResetsAt).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, QuotaErrorResponse{
+				Error:     "daily swipe limit reached",
+				Direction: string(quotaErr.Direction),
+				Limit:     quotaErr.Limit,
+				ResetsAt:  quotaErr.ResetsAt,
+			})
+			return
+		}
+
 		statusCode := http.StatusInternalServerError
 		message := "failed to create swipe"
 
@@ -62,6 +88,12 @@ func (h *SwipeHandler) CreateSwipe(c *gin.Context) {
 		case domain.ErrSwipeAlreadyExists:
 			statusCode = http.StatusConflict
 			message = "swipe already exists"
+		case domain.ErrUserNotFound:
+			statusCode = http.StatusNotFound
+			message = "user not found"
+		case domain.ErrUserBanned:
+			statusCode = http.StatusForbidden
+			message = "user is banned"
 		}
 
 		c.JSON(statusCode, ErrorResponse{
@@ -73,9 +105,83 @@ func (h *SwipeHandler) CreateSwipe(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// UndoSwipe handles POST /swipe/undo
+// @Summary Undo the last swipe
+// @Description Revert the most recent swipe within the undo window. Undoing a like deactivates the match it created
+// @Tags swipe
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} swipe.UndoSwipeResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /swipe/undo [post]
+func (h *SwipeHandler) UndoSwipe(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	result, err := h.swipeUseCase.UndoLastSwipe(c.Request.Context(), userID.(int))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		message := "failed to undo swipe"
+
+		switch err {
+		case domain.ErrSwipeNotFound:
+			statusCode = http.StatusNotFound
+			message = "no swipe to undo"
+		case domain.ErrUndoWindowExpired:
+			statusCode = http.StatusConflict
+			message = "undo window expired"
+		}
+
+		c.JSON(statusCode, ErrorResponse{
+			Error: message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetQuota handles GET /swipe/quota
+// @Summary Get daily swipe quotas
+// @Description Get today's like and super-like usage. Quotas reset at midnight in the user's timezone
+// @Tags swipe
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} swipe.QuotaResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /swipe/quota [get]
+func (h *SwipeHandler) GetQuota(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	quota, err := h.swipeUseCase.GetQuota(c.Request.Context(), userID.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to get swipe quota",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
+
 // GetLikesReceived handles GET /swipe/likes-received
 // @Summary Get likes received
-// @Description Get list of users who liked current user
+// @Description Get list of users who liked current user, super-likes first
 // @Tags swipe
 // @Security BearerAuth
 // @Produce json
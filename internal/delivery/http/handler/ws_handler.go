@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/realtime"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/message"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+type WSHandler struct {
+	hub            *realtime.Hub
+	messageUseCase *message.MessageUseCase
+	upgrader       websocket.Upgrader
+	log            *slog.Logger
+}
+
+func NewWSHandler(hub *realtime.Hub, messageUseCase *message.MessageUseCase, log *slog.Logger) *WSHandler {
+	return &WSHandler{
+		hub:            hub,
+		messageUseCase: messageUseCase,
+		log:            log,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Origins are not restricted, same as CORSMiddleware
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Connect handles GET /ws
+// @Summary Real-time events
+// @Description Upgrade to WebSocket to receive new messages, matches, likes, typing indicators and read receipts.
+// @Description Clients may send {"type":"typing","match_id":N}.
+// @Tags realtime
+// @Security BearerAuth
+// @Param token query string false "Access token (alternative to Authorization header)"
+// @Success 101 "Switching Protocols"
+// @Failure 401 {object} ErrorResponse
+// @Router /ws [get]
+func (h *WSHandler) Connect(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "unauthorized",
+		})
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrader already wrote the error response
+		return
+	}
+
+	h.hub.Serve(c.Request.Context(), conn, userID.(int), h.handleClientMessage)
+}
+
+// handleClientMessage dispatches messages sent by clients over the socket
+func (h *WSHandler) handleClientMessage(ctx context.Context, userID int, msg *realtime.ClientMessage) {
+	switch msg.Type {
+	case "typing":
+		if err := h.messageUseCase.SendTyping(ctx, userID, msg.MatchID); err != nil {
+			h.log.WarnContext(ctx, "typing event rejected", "user_id", userID, "error", err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
 	"github.com/gdugdh24/mpit2026-backend/internal/usecase/auth"
 	"github.com/gin-gonic/gin"
 )
@@ -40,36 +41,92 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
-		token := parts[1]
+		if !m.authenticate(c, parts[1]) {
+			return
+		}
+
+		c.Next()
+	}
+}
 
-		// Verify token
-		userID, err := m.authUseCase.VerifyToken(c.Request.Context(), token)
-		if err != nil {
-			statusCode := http.StatusUnauthorized
-			message := "invalid token"
-
-			switch err.Error() {
-			case "session not found":
-				message = "session not found"
-			case "session expired":
-				message = "session expired"
+// RequireWSAuth validates JWT token for WebSocket upgrades.
+// Browsers cannot set headers on WebSocket requests, so the token may also be
+// passed as the "token" query parameter.
+func (m *AuthMiddleware) RequireWSAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.Query("token")
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			parts := strings.Split(authHeader, " ")
+			if len(parts) == 2 && parts[0] == "Bearer" {
+				token = parts[1]
 			}
+		}
 
-			c.JSON(statusCode, gin.H{
-				"error": message,
+		if token == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "missing token",
 			})
 			c.Abort()
 			return
 		}
 
-		// Set user_id in context for handlers
-		c.Set("user_id", userID)
-		c.Set("token", token)
+		if !m.authenticate(c, token) {
+			return
+		}
 
 		c.Next()
 	}
 }
 
+// authenticate verifies token and sets user_id and user_role in context, aborting on failure
+func (m *AuthMiddleware) authenticate(c *gin.Context, token string) bool {
+	claims, err := m.authUseCase.VerifyToken(c.Request.Context(), token)
+	if err != nil {
+		statusCode := http.StatusUnauthorized
+		message := "invalid token"
+
+		switch err.Error() {
+		case "session not found":
+			message = "session not found"
+		case "session expired":
+			message = "session expired"
+		case "token expired":
+			message = "token expired"
+		}
+
+		c.JSON(statusCode, gin.H{
+			"error": message,
+		})
+		c.Abort()
+		return false
+	}
+
+	// Set user_id and user_role in context for handlers
+	c.Set("user_id", claims.UserID)
+	c.Set("user_role", domain.UserRole(claims.Role))
+	c.Set("token", token)
+
+	return true
+}
+
+// RequireRole allows only users with one of the given roles, it must run after RequireAuth
+func (m *AuthMiddleware) RequireRole(roles ...domain.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("user_role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "insufficient permissions",
+		})
+		c.Abort()
+	}
+}
+
 // OptionalAuth is a middleware that validates token if present but doesn't require it
 func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -86,9 +143,10 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		}
 
 		token := parts[1]
-		userID, err := m.authUseCase.VerifyToken(c.Request.Context(), token)
+		claims, err := m.authUseCase.VerifyToken(c.Request.Context(), token)
 		if err == nil {
-			c.Set("user_id", userID)
+			c.Set("user_id", claims.UserID)
+			c.Set("user_role", domain.UserRole(claims.Role))
 			c.Set("token", token)
 		}
 
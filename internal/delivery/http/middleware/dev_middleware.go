@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DevSecretHeader carries the shared secret for development-only endpoints
+const DevSecretHeader = "X-Dev-Secret"
+
+// RequireDevSecret allows the request only if it carries the shared dev secret
+func RequireDevSecret(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader(DevSecretHeader)
+		if secret == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(secret)) != 1 {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "forbidden",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that hit no route, so scans of random
+// paths share one series
+const unmatchedRoute = "unmatched"
+
+// Metrics observes the latency of every request by route template.
+// WebSocket connections are skipped, their duration is the session length.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.IsWebsocket() {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -1,52 +1,112 @@
 package http
 
 import (
+	"log/slog"
+	"strings"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/config"
 	"github.com/gdugdh24/mpit2026-backend/internal/delivery/http/handler"
 	"github.com/gdugdh24/mpit2026-backend/internal/delivery/http/middleware"
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/storage"
 	"github.com/gin-gonic/gin"
 )
 
 type Router struct {
-	authHandler    *handler.AuthHandler
-	profileHandler *handler.ProfileHandler
-	bigFiveHandler *handler.BigFiveHandler
-	feedHandler    *handler.FeedHandler
-	swipeHandler   *handler.SwipeHandler
-	authMiddleware *middleware.AuthMiddleware
+	authHandler         *handler.AuthHandler
+	profileHandler      *handler.ProfileHandler
+	photoHandler        *handler.PhotoHandler
+	interestHandler     *handler.InterestHandler
+	bigFiveHandler      *handler.BigFiveHandler
+	feedHandler         *handler.FeedHandler
+	swipeHandler        *handler.SwipeHandler
+	matchHandler        *handler.MatchHandler
+	messageHandler      *handler.MessageHandler
+	notificationHandler *handler.NotificationHandler
+	moderationHandler   *handler.ModerationHandler
+	adminHandler        *handler.AdminHandler
+	wsHandler           *handler.WSHandler
+	healthHandler       *handler.HealthHandler
+	authMiddleware      *middleware.AuthMiddleware
+	serverConfig        *config.ServerConfig
+	fileStorage         storage.Storage
+	log                 *slog.Logger
 }
 
 func NewRouter(
 	authHandler *handler.AuthHandler,
 	profileHandler *handler.ProfileHandler,
+	photoHandler *handler.PhotoHandler,
+	interestHandler *handler.InterestHandler,
 	bigFiveHandler *handler.BigFiveHandler,
 	feedHandler *handler.FeedHandler,
 	swipeHandler *handler.SwipeHandler,
+	matchHandler *handler.MatchHandler,
+	messageHandler *handler.MessageHandler,
+	notificationHandler *handler.NotificationHandler,
+	moderationHandler *handler.ModerationHandler,
+	adminHandler *handler.AdminHandler,
+	wsHandler *handler.WSHandler,
+	healthHandler *handler.HealthHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	serverConfig *config.ServerConfig,
+	fileStorage storage.Storage,
+	log *slog.Logger,
 ) *Router {
 	return &Router{
-		authHandler:    authHandler,
-		profileHandler: profileHandler,
-		bigFiveHandler: bigFiveHandler,
-		feedHandler:    feedHandler,
-		swipeHandler:   swipeHandler,
-		authMiddleware: authMiddleware,
+		authHandler:         authHandler,
+		profileHandler:      profileHandler,
+		photoHandler:        photoHandler,
+		interestHandler:     interestHandler,
+		bigFiveHandler:      bigFiveHandler,
+		feedHandler:         feedHandler,
+		swipeHandler:        swipeHandler,
+		matchHandler:        matchHandler,
+		messageHandler:      messageHandler,
+		notificationHandler: notificationHandler,
+		moderationHandler:   moderationHandler,
+		adminHandler:        adminHandler,
+		wsHandler:           wsHandler,
+		healthHandler:       healthHandler,
+		authMiddleware:      authMiddleware,
+		serverConfig:        serverConfig,
+		fileStorage:         fileStorage,
+		log:                 log,
 	}
 }
 
 func (r *Router) Setup() *gin.Engine {
-	router := gin.Default()
+	// Route listing and other gin debug output only make sense locally
+	if !r.serverConfig.IsDevelopment() {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	router := gin.New()
+
+	// Request ID first so recovery and access logs carry it. The access log
+	// reads the request context after the handlers ran, so it also gets the
+	// trace ID of the span started by Tracing.
+	router.Use(middleware.RequestID())
+	router.Use(middleware.AccessLog(r.log))
+	router.Use(middleware.Metrics())
+	router.Use(middleware.Tracing())
+	router.Use(middleware.Recovery(r.log))
 
 	// Add CORS middleware
 	router.Use(middleware.CORSMiddleware())
 
-	// Health check (supports both GET and HEAD)
-	healthHandler := func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "ok",
-		})
+	// Probes (support both GET and HEAD). /health is kept for existing
+	// health checks and is a liveness probe, /readyz checks dependencies.
+	router.GET("/health", r.healthHandler.Livez)
+	router.HEAD("/health", r.healthHandler.Livez)
+	router.GET("/livez", r.healthHandler.Livez)
+	router.HEAD("/livez", r.healthHandler.Livez)
+	router.GET("/readyz", r.healthHandler.Readyz)
+	router.HEAD("/readyz", r.healthHandler.Readyz)
+
+	// Uploaded files of the local storage driver are served by the app itself
+	if local, ok := r.fileStorage.(*storage.LocalStorage); ok && strings.HasPrefix(local.PublicURL(), "/") {
+		router.Static(local.PublicURL(), local.Root())
 	}
-	router.GET("/health", healthHandler)
-	router.HEAD("/health", healthHandler)
 
 	// API v1
 	v1 := router.Group("/api/v1")
@@ -55,8 +115,15 @@ func (r *Router) Setup() *gin.Engine {
 		auth := v1.Group("/auth")
 		{
 			auth.POST("/vk", r.authHandler.VKAuth)
-			auth.POST("/test", r.authHandler.TestAuth) // Test endpoint for development
+			// Test endpoint bypasses VK verification: development/test only, behind the dev secret
+			if r.serverConfig.IsDevelopment() && r.serverConfig.DevAuthSecret != "" {
+				auth.POST("/test", middleware.RequireDevSecret(r.serverConfig.DevAuthSecret), r.authHandler.TestAuth)
+			}
+			auth.POST("/refresh", r.authHandler.Refresh)
 			auth.POST("/logout", r.authMiddleware.RequireAuth(), r.authHandler.Logout)
+			auth.POST("/logout-all", r.authMiddleware.RequireAuth(), r.authHandler.LogoutAll)
+			auth.GET("/sessions", r.authMiddleware.RequireAuth(), r.authHandler.ListSessions)
+			auth.DELETE("/sessions/:id", r.authMiddleware.RequireAuth(), r.authHandler.RevokeSession)
 			auth.GET("/me", r.authMiddleware.RequireAuth(), r.authHandler.Me)
 		}
 
@@ -71,6 +138,12 @@ func (r *Router) Setup() *gin.Engine {
 				profile.PUT("/me", r.profileHandler.UpdateMyProfile)
 				profile.POST("/complete-onboarding", r.profileHandler.CompleteOnboarding)
 				profile.POST("/generate-bio", r.profileHandler.GenerateBio)
+				profile.POST("/import-vk", r.profileHandler.ImportVK)
+				profile.GET("/photos", r.photoHandler.ListPhotos)
+				profile.POST("/photos", r.photoHandler.UploadPhoto)
+				profile.DELETE("/photos/:id", r.photoHandler.DeletePhoto)
+				profile.PUT("/photos/:id/primary", r.photoHandler.SetPrimary)
+				profile.PUT("/photos/:id/order", r.photoHandler.MovePhoto)
 				profile.GET("/:user_id", r.profileHandler.GetProfileByUserID)
 			}
 
@@ -85,6 +158,7 @@ func (r *Router) Setup() *gin.Engine {
 			// Feed routes
 			feed := protected.Group("/feed")
 			{
+				feed.GET("", r.feedHandler.GetFeed)
 				feed.GET("/next", r.feedHandler.GetNextUser)
 				feed.POST("/reset-dislikes", r.feedHandler.ResetDislikes)
 			}
@@ -94,16 +168,76 @@ func (r *Router) Setup() *gin.Engine {
 			{
 				swipe.POST("", r.swipeHandler.CreateSwipe)
 				swipe.GET("/likes-received", r.swipeHandler.GetLikesReceived)
+				swipe.POST("/undo", r.swipeHandler.UndoSwipe)
+				swipe.GET("/quota", r.swipeHandler.GetQuota)
+			}
+
+			// Match routes
+			matches := protected.Group("/matches")
+			{
+				matches.GET("", r.matchHandler.GetMatches)
+				matches.GET("/:id", r.matchHandler.GetMatch)
+				matches.DELETE("/:id", r.matchHandler.Unmatch)
+
+				// Message routes
+				matches.GET("/:id/messages", r.messageHandler.GetMessages)
+				matches.POST("/:id/messages", r.messageHandler.SendMessage)
+				matches.POST("/:id/messages/read-all", r.messageHandler.MarkAllAsRead)
+				matches.POST("/:id/messages/:message_id/read", r.messageHandler.MarkAsRead)
+			}
+
+			// Notification routes
+			notifications := protected.Group("/notifications")
+			{
+				notifications.GET("", r.notificationHandler.GetNotifications)
+				notifications.GET("/unread-count", r.notificationHandler.GetUnreadCount)
+				notifications.POST("/read-all", r.notificationHandler.MarkAllAsRead)
+				notifications.POST("/:id/read", r.notificationHandler.MarkAsRead)
+			}
+
+			// Block and report routes
+			users := protected.Group("/users")
+			{
+				users.POST("/:id/block", r.moderationHandler.BlockUser)
+				users.POST("/:id/report", r.moderationHandler.ReportUser)
 			}
 
-			// TODO: Add match routes
-			// TODO: Add message routes
-			// TODO: Add notification routes
 			// TODO: Add dashboard /me route
 		}
 
+		// Admin routes, every action is written to the audit log
+		adminGroup := v1.Group("/admin")
+		adminGroup.Use(
+			r.authMiddleware.RequireAuth(),
+			r.authMiddleware.RequireRole(domain.RoleModerator, domain.RoleAdmin),
+		)
+		{
+			requireAdmin := r.authMiddleware.RequireRole(domain.RoleAdmin)
+
+			adminGroup.GET("/users", r.adminHandler.SearchUsers)
+			adminGroup.GET("/users/:id", r.adminHandler.GetUser)
+			adminGroup.GET("/users/:id/swipes", r.adminHandler.GetUserSwipes)
+			adminGroup.GET("/users/:id/matches", r.adminHandler.GetUserMatches)
+			adminGroup.POST("/users/:id/shadow-ban", r.adminHandler.ShadowBanUser)
+			adminGroup.POST("/users/:id/shadow-unban", r.adminHandler.UnshadowBanUser)
+			adminGroup.POST("/users/:id/ban", requireAdmin, r.adminHandler.BanUser)
+			adminGroup.POST("/users/:id/unban", requireAdmin, r.adminHandler.UnbanUser)
+			adminGroup.POST("/users/:id/expire-sessions", requireAdmin, r.adminHandler.ExpireSessions)
+
+			adminGroup.GET("/reports", r.adminHandler.ListReports)
+			adminGroup.POST("/reports/:id/resolve", r.adminHandler.ResolveReport)
+
+			adminGroup.GET("/audit-log", requireAdmin, r.adminHandler.ListAuditLog)
+		}
+
+		// Real-time events (WebSocket, token via header or ?token=)
+		v1.GET("/ws", r.authMiddleware.RequireWSAuth(), r.wsHandler.Connect)
+
 		// Big Five questions (public)
 		v1.GET("/big-five/questions", r.bigFiveHandler.GetQuestions)
+
+		// Interest catalog and autocomplete (public)
+		v1.GET("/interests", r.interestHandler.SearchInterests)
 	}
 
 	return router
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditUserSearch      AuditAction = "user.search"
+	AuditUserView        AuditAction = "user.view"
+	AuditUserSwipesView  AuditAction = "user.swipes.view"
+	AuditUserMatchesView AuditAction = "user.matches.view"
+	AuditUserBan         AuditAction = "user.ban"
+	AuditUserUnban       AuditAction = "user.unban"
+	AuditUserShadowBan   AuditAction = "user.shadow_ban"
+	AuditUserShadowUnban AuditAction = "user.shadow_unban"
+	AuditSessionsExpire  AuditAction = "user.sessions.expire"
+	AuditReportsView     AuditAction = "report.list"
+	AuditReportResolve   AuditAction = "report.resolve"
+	AuditLogView         AuditAction = "audit.list"
+)
+
+// AuditEntry is an append-only record of an admin or moderator action
+type AuditEntry struct {
+	ID         int64           `json:"id" db:"id"`
+	ActorID    int             `json:"actor_id" db:"actor_id"`
+	ActorRole  UserRole        `json:"actor_role" db:"actor_role"`
+	Action     AuditAction     `json:"action" db:"action"`
+	TargetType string          `json:"target_type" db:"target_type"`
+	TargetID   *int            `json:"target_id" db:"target_id"`
+	Details    json.RawMessage `json:"details" db:"details"`
+	IPAddress  *string         `json:"ip_address" db:"ip_address"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
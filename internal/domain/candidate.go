@@ -0,0 +1,9 @@
+package domain
+
+// Candidate is a feed candidate: a profile hydrated with its user
+type Candidate struct {
+	Profile *Profile
+	User    *User
+	// SuperLiked is set when the candidate super-liked the viewer
+	SuperLiked bool
+}
@@ -9,19 +9,33 @@ var (
 	ErrInvalidCredentials   = errors.New("invalid credentials")
 	ErrInvalidVKSignature   = errors.New("invalid VK signature")
 	ErrVKTokenExpired       = errors.New("VK token expired")
+	ErrUserBanned           = errors.New("user is banned")
 
 	// Profile errors
 	ErrProfileNotFound      = errors.New("profile not found")
 	ErrProfileAlreadyExists = errors.New("profile already exists")
+	ErrUnknownInterest      = errors.New("unknown interest")
+
+	// Photo errors
+	ErrPhotoNotFound        = errors.New("photo not found")
+	ErrPhotoLimitReached    = errors.New("photo limit reached")
+	ErrPhotoAlreadyImported = errors.New("photo already imported")
+	ErrUnsupportedImage     = errors.New("unsupported image")
+	ErrImageTooLarge        = errors.New("image too large")
 
 	// Session errors
 	ErrSessionNotFound      = errors.New("session not found")
 	ErrSessionExpired       = errors.New("session expired")
 	ErrInvalidToken         = errors.New("invalid token")
+	ErrTokenExpired         = errors.New("token expired")
+	ErrRefreshTokenReused   = errors.New("refresh token reused")
 
 	// Swipe errors
 	ErrSwipeAlreadyExists   = errors.New("swipe already exists")
 	ErrCannotSwipeSelf      = errors.New("cannot swipe yourself")
+	ErrSwipeNotFound        = errors.New("swipe not found")
+	ErrUndoWindowExpired    = errors.New("undo window expired")
+	ErrQuotaExceeded        = errors.New("daily swipe quota exceeded")
 
 	// Match errors
 	ErrMatchNotFound        = errors.New("match not found")
@@ -32,6 +46,19 @@ var (
 	ErrMessageNotFound      = errors.New("message not found")
 	ErrUnauthorizedMessage  = errors.New("unauthorized to access message")
 
+	// Moderation errors
+	ErrCannotBlockSelf      = errors.New("cannot block yourself")
+	ErrCannotReportSelf     = errors.New("cannot report yourself")
+	ErrReportNotFound       = errors.New("report not found")
+	ErrReportResolved       = errors.New("report already resolved")
+	ErrTargetOutranks       = errors.New("target has an equal or higher role")
+
+	// Job errors
+	ErrJobLeaseLost         = errors.New("job lease lost")
+
+	// Notification errors
+	ErrNotificationNotFound = errors.New("notification not found")
+
 	// General errors
 	ErrInvalidInput         = errors.New("invalid input")
 	ErrUnauthorized         = errors.New("unauthorized")
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type EventType string
+
+const (
+	EventMessageNew  EventType = "message.new"
+	EventMessageRead EventType = "message.read"
+	EventMatchNew    EventType = "match.new"
+	EventLikeNew     EventType = "like.new"
+	EventTyping      EventType = "typing"
+
+	EventNotificationNew EventType = "notification.new"
+
+	// EventMatchAIReady carries the explanation and icebreakers generated after a match
+	EventMatchAIReady EventType = "match.ai_ready"
+)
+
+// Event is a real-time event pushed to a user's connected clients
+type Event struct {
+	Type      EventType   `json:"type"`
+	Payload   interface{} `json:"payload"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func NewEvent(eventType EventType, payload interface{}) *Event {
+	return &Event{
+		Type:      eventType,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InterestCategory groups related interests, interests in the same category
+// count as partially shared in the feed score
+type InterestCategory struct {
+	Slug   string `json:"slug" db:"slug"`
+	Name   string `json:"name" db:"name_ru"`
+	NameEn string `json:"name_en" db:"name_en"`
+}
+
+// Interest is a catalog entry. Profiles store the Slug, Name, NameEn and
+// Synonyms are the spellings that resolve to it.
+type Interest struct {
+	Slug         string   `json:"slug" db:"slug"`
+	Name         string   `json:"name" db:"name_ru"`
+	NameEn       string   `json:"name_en" db:"name_en"`
+	Category     string   `json:"category" db:"category_slug"`
+	CategoryName string   `json:"category_name" db:"category_name_ru"`
+	Synonyms     []string `json:"-" db:"synonyms"`
+}
+
+// UnknownInterestError lists submitted interests missing from the catalog.
+// It matches ErrUnknownInterest with errors.Is.
+type UnknownInterestError struct {
+	Values []string
+}
+
+func (e *UnknownInterestError) Error() string {
+	return fmt.Sprintf("unknown interests: %s", strings.Join(e.Values, ", "))
+}
+
+func (e *UnknownInterestError) Is(target error) bool {
+	return target == ErrUnknownInterest
+}
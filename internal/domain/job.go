@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type JobStatus string
+
+const (
+	JobPending JobStatus = "pending"
+	JobRunning JobStatus = "running"
+	// JobDead jobs ran out of attempts and are no longer retried
+	JobDead JobStatus = "dead"
+)
+
+// DefaultJobMaxAttempts is how often a job runs before it is dead-lettered
+const DefaultJobMaxAttempts = 5
+
+// Job is a unit of background work stored in the jobs table
+type Job struct {
+	ID          int64           `json:"id" db:"id"`
+	Type        string          `json:"type" db:"type"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	Status      JobStatus       `json:"status" db:"status"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	MaxAttempts int             `json:"max_attempts" db:"max_attempts"`
+	RunAt       time.Time       `json:"run_at" db:"run_at"`
+	LockedUntil *time.Time      `json:"locked_until" db:"locked_until"`
+	LastError   *string         `json:"last_error" db:"last_error"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// NewJob creates a job due now with the payload encoded as JSON
+func NewJob(jobType string, payload interface{}) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Job{
+		Type:        jobType,
+		Payload:     data,
+		Status:      JobPending,
+		MaxAttempts: DefaultJobMaxAttempts,
+		RunAt:       time.Now(),
+	}, nil
+}
@@ -9,6 +9,7 @@ type Match struct {
 	IsActive    bool      `json:"is_active" db:"is_active"`
 	Explanation *string   `json:"explanation" db:"match_explanation"`
 	Icebreakers []string  `json:"icebreakers" db:"icebreakers"`
+	AIFallback  bool      `json:"ai_fallback" db:"ai_fallback"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
@@ -1,11 +1,27 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+type NotificationType string
+
+const (
+	// NotificationSystem is the type of notifications created before types were introduced
+	NotificationSystem           NotificationType = "system"
+	NotificationNewMatch         NotificationType = "new_match"
+	NotificationNewLike          NotificationType = "new_like"
+	NotificationNewMessage       NotificationType = "new_message"
+	NotificationIcebreakerReady  NotificationType = "icebreaker_ready"
+	NotificationModerationResult NotificationType = "moderation_result"
+)
 
 type Notification struct {
-	ID        int       `json:"id" db:"id"`
-	UserID    int       `json:"user_id" db:"user_id"`
-	Content   string    `json:"content" db:"content"`
-	IsRead    bool      `json:"is_read" db:"is_read"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID        int              `json:"id" db:"id"`
+	UserID    int              `json:"user_id" db:"user_id"`
+	Type      NotificationType `json:"type" db:"type"`
+	Payload   json.RawMessage  `json:"payload" db:"payload"`
+	IsRead    bool             `json:"is_read" db:"is_read"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
 }
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// MaxPhotosPerUser is how many photos a profile can have
+const MaxPhotosPerUser = 6
+
+// Photo is a profile photo. Files are kept by the storage driver, URL and
+// ThumbnailURL are filled in from the storage keys when the photo is returned.
+// SourceURL is only set for photos imported from another service such as VK.
+type Photo struct {
+	ID           int       `json:"id" db:"id"`
+	UserID       int       `json:"user_id" db:"user_id"`
+	StorageKey   string    `json:"-" db:"storage_key"`
+	ThumbnailKey string    `json:"-" db:"thumbnail_key"`
+	Width        int       `json:"width" db:"width"`
+	Height       int       `json:"height" db:"height"`
+	SizeBytes    int       `json:"size_bytes" db:"size_bytes"`
+	Position     int       `json:"position" db:"position"`
+	IsPrimary    bool      `json:"is_primary" db:"is_primary"`
+	SourceURL    *string   `json:"-" db:"source_url"`
+	URL          string    `json:"url" db:"-"`
+	ThumbnailURL string    `json:"thumbnail_url" db:"-"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
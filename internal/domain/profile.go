@@ -2,6 +2,62 @@ package domain
 
 import "time"
 
+// InterestedIn is who the user wants to see in the feed
+type InterestedIn string
+
+const (
+	InterestedInMen      InterestedIn = "men"
+	InterestedInWomen    InterestedIn = "women"
+	InterestedInEveryone InterestedIn = "everyone"
+)
+
+// Accepts reports whether a user of the given gender matches the preference
+func (i InterestedIn) Accepts(gender Gender) bool {
+	switch i {
+	case InterestedInMen:
+		return gender == GenderMale
+	case InterestedInWomen:
+		return gender == GenderFemale
+	default:
+		return true
+	}
+}
+
+// Genders returns genders matching the preference, nil means any
+func (i InterestedIn) Genders() []Gender {
+	switch i {
+	case InterestedInMen:
+		return []Gender{GenderMale}
+	case InterestedInWomen:
+		return []Gender{GenderFemale}
+	default:
+		return nil
+	}
+}
+
+// InterestedInAccepting returns preferences that accept a user of the given gender
+func InterestedInAccepting(gender Gender) []InterestedIn {
+	accepting := []InterestedIn{InterestedInEveryone}
+	for _, i := range []InterestedIn{InterestedInMen, InterestedInWomen} {
+		if i.Accepts(gender) {
+			accepting = append(accepting, i)
+		}
+	}
+	return accepting
+}
+
+// DefaultInterestedIn keeps the old opposite-gender feed for users who didn't set a preference
+func DefaultInterestedIn(gender Gender) InterestedIn {
+	switch gender {
+	case GenderMale:
+		return InterestedInWomen
+	case GenderFemale:
+		return InterestedInMen
+	default:
+		return InterestedInEveryone
+	}
+}
+
 type Profile struct {
 	ID                   int        `json:"id" db:"id"`
 	UserID               int        `json:"user_id" db:"user_id"`
@@ -15,6 +71,7 @@ type Profile struct {
 	PrefMinAge           *int       `json:"pref_min_age" db:"pref_min_age"`
 	PrefMaxAge           *int       `json:"pref_max_age" db:"pref_max_age"`
 	PrefMaxDistanceKm    *int       `json:"pref_max_distance_km" db:"pref_max_distance_km"`
+	InterestedIn         InterestedIn `json:"interested_in" db:"interested_in"`
 	PrefOpenness          *float64   `json:"pref_openness" db:"pref_openness"`
 	PrefConscientiousness *float64   `json:"pref_conscientiousness" db:"pref_conscientiousness"`
 	PrefExtraversion      *float64   `json:"pref_extraversion" db:"pref_extraversion"`
@@ -24,3 +81,32 @@ type Profile struct {
 	CreatedAt            time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt            time.Time  `json:"updated_at" db:"updated_at"`
 }
+
+// PreferenceVector is the learned Big Five "ideal partner" vector of a profile
+type PreferenceVector struct {
+	Openness          *float64 `json:"openness"`
+	Conscientiousness *float64 `json:"conscientiousness"`
+	Extraversion      *float64 `json:"extraversion"`
+	Agreeableness     *float64 `json:"agreeableness"`
+	Neuroticism       *float64 `json:"neuroticism"`
+}
+
+// Preferences returns the current preference vector
+func (p *Profile) Preferences() PreferenceVector {
+	return PreferenceVector{
+		Openness:          p.PrefOpenness,
+		Conscientiousness: p.PrefConscientiousness,
+		Extraversion:      p.PrefExtraversion,
+		Agreeableness:     p.PrefAgreeableness,
+		Neuroticism:       p.PrefNeuroticism,
+	}
+}
+
+// SetPreferences replaces the preference vector
+func (p *Profile) SetPreferences(v PreferenceVector) {
+	p.PrefOpenness = v.Openness
+	p.PrefConscientiousness = v.Conscientiousness
+	p.PrefExtraversion = v.Extraversion
+	p.PrefAgreeableness = v.Agreeableness
+	p.PrefNeuroticism = v.Neuroticism
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+type ReportReason string
+
+const (
+	ReportReasonSpam       ReportReason = "spam"
+	ReportReasonFake       ReportReason = "fake"
+	ReportReasonHarassment ReportReason = "harassment"
+	ReportReasonUnderage   ReportReason = "underage"
+	ReportReasonOther      ReportReason = "other"
+)
+
+type ReportStatus string
+
+const (
+	ReportStatusPending   ReportStatus = "pending"
+	ReportStatusResolved  ReportStatus = "resolved"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+// Report is an entry in the moderation queue
+type Report struct {
+	ID         int          `json:"id" db:"id"`
+	ReporterID int          `json:"reporter_id" db:"reporter_id"`
+	ReportedID int          `json:"reported_id" db:"reported_id"`
+	Reason     ReportReason `json:"reason" db:"reason"`
+	Details    *string      `json:"details" db:"details"`
+	// MessageID optionally points to the message used as evidence
+	MessageID  *int         `json:"message_id" db:"message_id"`
+	Status     ReportStatus `json:"status" db:"status"`
+	ResolvedBy *int         `json:"resolved_by" db:"resolved_by"`
+	Resolution *string      `json:"resolution" db:"resolution"`
+	ResolvedAt *time.Time   `json:"resolved_at" db:"resolved_at"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+}
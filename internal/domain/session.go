@@ -2,16 +2,29 @@ package domain
 
 import "time"
 
+// Session is a single refresh token. Token holds the SHA-256 hash of the token.
+// Refreshing rotates the session into a new row of the same family.
 type Session struct {
-	ID         int       `json:"id" db:"id"`
-	UserID     int       `json:"user_id" db:"user_id"`
-	Token      string    `json:"token" db:"token"`
-	DeviceInfo *string   `json:"device_info" db:"device_info"`
-	IPAddress  *string   `json:"ip_address" db:"ip_address"`
-	ExpiresAt  time.Time `json:"expires_at" db:"expires_at"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	FamilyID   string     `json:"family_id" db:"family_id"`
+	Token      string     `json:"-" db:"token"`
+	DeviceInfo *string    `json:"device_info" db:"device_info"`
+	IPAddress  *string    `json:"ip_address" db:"ip_address"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty" db:"rotated_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
 }
 
 func (s *Session) IsExpired() bool {
 	return time.Now().After(s.ExpiresAt)
 }
+
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+func (s *Session) IsRotated() bool {
+	return s.RotatedAt != nil
+}
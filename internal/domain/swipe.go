@@ -1,11 +1,59 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type SwipeDirection string
+
+const (
+	SwipeLeft  SwipeDirection = "left"
+	SwipeRight SwipeDirection = "right"
+	SwipeSuper SwipeDirection = "super"
+)
+
+// IsLike reports whether the direction counts as a like (right or super)
+func (d SwipeDirection) IsLike() bool {
+	return d == SwipeRight || d == SwipeSuper
+}
 
 type Swipe struct {
-	ID        int       `json:"id" db:"id"`
-	SwiperID  int       `json:"swiper_id" db:"swiper_id"`
-	SwipedID  int       `json:"swiped_id" db:"swiped_id"`
-	IsLike    bool      `json:"is_like" db:"is_like"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID        int            `json:"id" db:"id"`
+	SwiperID  int            `json:"swiper_id" db:"swiper_id"`
+	SwipedID  int            `json:"swiped_id" db:"swiped_id"`
+	IsLike    bool           `json:"is_like" db:"is_like"`
+	Direction SwipeDirection `json:"direction" db:"direction"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+	// PrefSnapshot is the swiper's PreferenceVector before this like was learned,
+	// kept so the swipe can be undone
+	PrefSnapshot json.RawMessage `json:"-" db:"pref_snapshot"`
+}
+
+// IsSuperLike reports whether the swipe is a super-like
+func (s *Swipe) IsSuperLike() bool {
+	return s.Direction == SwipeSuper
+}
+
+// SwipeQuota is the usage of a daily swipe limit in the current window
+type SwipeQuota struct {
+	Used     int       `db:"used"`
+	ResetsAt time.Time `db:"resets_at"`
+}
+
+// QuotaExceededError is returned when a user runs out of daily likes or
+// super-likes. It matches ErrQuotaExceeded with errors.Is.
+type QuotaExceededError struct {
+	Direction SwipeDirection
+	Limit     int
+	ResetsAt  time.Time
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("daily %s swipe limit of %d reached", e.Direction, e.Limit)
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
 }
@@ -5,10 +5,22 @@ import "time"
 type Gender string
 
 const (
-	GenderMale   Gender = "male"
-	GenderFemale Gender = "female"
+	GenderMale      Gender = "male"
+	GenderFemale    Gender = "female"
+	GenderNonBinary Gender = "non_binary"
 )
 
+type UserRole string
+
+const (
+	RoleUser      UserRole = "user"
+	RoleModerator UserRole = "moderator"
+	RoleAdmin     UserRole = "admin"
+)
+
+// DefaultTimezone is used for daily quotas until the user sets their own
+const DefaultTimezone = "Europe/Moscow"
+
 type User struct {
 	ID                int        `json:"id" db:"id"`
 	VKID              int        `json:"vk_id" db:"vk_id"`
@@ -18,6 +30,12 @@ type User struct {
 	BirthDate         time.Time  `json:"birth_date" db:"birth_date"`
 	IsVerified        bool       `json:"is_verified" db:"is_verified"`
 	IsOnline          bool       `json:"is_online" db:"is_online"`
+	IsSynthetic       bool       `json:"is_synthetic" db:"is_synthetic"`
+	Timezone          string     `json:"timezone" db:"timezone"`
+	Role              UserRole   `json:"role" db:"role"`
+	IsBanned          bool       `json:"-" db:"is_banned"`
+	// IsShadowBanned hides the user from everyone else without telling them
+	IsShadowBanned    bool       `json:"-" db:"is_shadow_banned"`
 	LastOnlineAt      *time.Time `json:"last_online_at" db:"last_online_at"`
 	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
@@ -27,6 +45,19 @@ func (u *User) Age() int {
 	return int(time.Since(u.BirthDate).Hours() / 24 / 365.25)
 }
 
+// Location returns the user's time zone, falling back to DefaultTimezone
+func (u *User) Location() *time.Location {
+	for _, name := range []string{u.Timezone, DefaultTimezone} {
+		if name == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
 func (u *User) IsAdult() bool {
 	return u.Age() >= 18
 }
@@ -1,104 +1,312 @@
 package container
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/config"
 	"github.com/gdugdh24/mpit2026-backend/internal/delivery/http"
 	"github.com/gdugdh24/mpit2026-backend/internal/delivery/http/handler"
 	"github.com/gdugdh24/mpit2026-backend/internal/delivery/http/middleware"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/cache"
 	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/database"
 	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/gemini"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/health"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/jobs"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/llm"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/metrics"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/realtime"
 	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/server"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/storage"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/tracing"
 	"github.com/gdugdh24/mpit2026-backend/internal/repository/postgres"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/admin"
 	"github.com/gdugdh24/mpit2026-backend/internal/usecase/auth"
 	"github.com/gdugdh24/mpit2026-backend/internal/usecase/bigfive"
 	"github.com/gdugdh24/mpit2026-backend/internal/usecase/feed"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/interest"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/match"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/message"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/moderation"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/notification"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/photo"
 	"github.com/gdugdh24/mpit2026-backend/internal/usecase/profile"
 	"github.com/gdugdh24/mpit2026-backend/internal/usecase/swipe"
+	"github.com/gdugdh24/mpit2026-backend/pkg/crypto"
+	"github.com/gdugdh24/mpit2026-backend/pkg/jwt"
+	"github.com/gdugdh24/mpit2026-backend/pkg/vkapi"
 	"github.com/jmoiron/sqlx"
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	// sessionCleanupInterval is how often expired sessions are purged
+	sessionCleanupInterval = time.Hour
+	// readinessTimeout bounds each dependency check of /readyz
+	readinessTimeout = 2 * time.Second
+	// tracingFlushTimeout bounds exporting the last spans on shutdown
+	tracingFlushTimeout = 5 * time.Second
+)
+
 // Container holds all application dependencies
 type Container struct {
 	Config *config.Config
 	DB     *sqlx.DB
 	Redis  *redis.Client
 	Server *server.Server
-	Gemini *gemini.GeminiClient
+	// Wingman is nil when AI features are disabled
+	Wingman llm.AIWingman
+	Hub     *realtime.Hub
+	Logger  *slog.Logger
+
+	stopBackground  context.CancelFunc
+	jobsDrained     <-chan struct{}
+	shutdownTracing tracing.Shutdown
 }
 
-// NewContainer creates a new dependency injection container
-func NewContainer(cfg *config.Config) (*Container, error) {
+// NewContainer creates a new dependency injection container.
+// log is handed to every component that logs.
+func NewContainer(cfg *config.Config, log *slog.Logger) (*Container, error) {
+	// Initialize tracing first so every component's spans are exported
+	shutdownTracing, err := tracing.Setup(context.Background(), &cfg.Tracing, cfg.Server.Env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
 	// Initialize database
 	db, err := database.NewPostgresDB(&cfg.Database)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
-	// // Initialize Redis
-	// redisClient, err := database.NewRedisClient(&cfg.Redis)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("failed to initialize redis: %w", err)
-	// }
+	// Initialize Redis (optional), real-time broker and cache.
+	// Redis pub/sub fans events out across instances and shares the cache,
+	// otherwise both stay in-process.
+	var redisClient *redis.Client
+	var broker realtime.Broker = realtime.NewMemoryBroker()
+	var appCache cache.Cache = cache.NewMemoryCache()
+	if cfg.Redis.Enabled {
+		redisClient, err = database.NewRedisClient(&cfg.Redis)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize redis: %w", err)
+		}
+		broker = realtime.NewRedisBroker(redisClient)
+		appCache = cache.NewRedisCache(redisClient)
+	}
+
+	hub := realtime.NewHub(broker)
+
+	// Export connection pool stats and set up readiness checks
+	if err := metrics.RegisterDB(db.DB, "postgres"); err != nil {
+		return nil, fmt.Errorf("failed to register database metrics: %w", err)
+	}
+
+	checker := health.NewChecker(readinessTimeout)
+	checker.Add("postgres", db.PingContext)
+	if redisClient != nil {
+		checker.Add("redis", func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		})
+	}
+	if cfg.ML.ServiceURL != "" {
+		checker.Add("ml_service", health.HTTPCheck(strings.TrimSuffix(cfg.ML.ServiceURL, "/")+"/health"))
+	}
 
-	// Initialize Gemini Client
-	geminiClient, err := gemini.NewGeminiClient(cfg.GeminiAPIKey)
+	// Initialize the AI wingman selected by AI_PROVIDER
+	wingman, err := newAIWingman(&cfg.AI, log)
 	if err != nil {
-		fmt.Printf("Warning: Failed to initialize Gemini client: %v\n", err)
+		log.Warn("failed to initialize AI wingman, AI features are disabled", "provider", cfg.AI.Provider, "error", err)
 		// Don't fail, just continue without AI features
 	}
 
+	// Initialize message encryptor (AES-256-GCM at rest)
+	encryptor, err := crypto.NewEncryptor(cfg.Encryption.AESKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize encryptor: %w", err)
+	}
+
+	// Initialize file storage for uploaded photos
+	fileStorage, err := storage.New(&cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	// Initialize token manager (short-lived access + rotating refresh tokens)
+	tokenManager := jwt.NewTokenManager(
+		cfg.JWT.AccessSecret,
+		cfg.JWT.RefreshSecret,
+		cfg.JWT.AccessExpiryMin,
+		cfg.JWT.RefreshExpiryDay,
+	)
+
 	// Initialize repositories
 	userRepo := postgres.NewUserRepository(db)
 	profileRepo := postgres.NewProfileRepository(db)
 	sessionRepo := postgres.NewSessionRepository(db)
 	swipeRepo := postgres.NewSwipeRepository(db)
 	matchRepo := postgres.NewMatchRepository(db)
-	// messageRepo := postgres.NewMessageRepository(db)
-	// notificationRepo := postgres.NewNotificationRepository(db)
+	messageRepo := postgres.NewMessageRepository(db)
+	notificationRepo := postgres.NewNotificationRepository(db)
 	bigFiveRepo := postgres.NewBigFiveRepository(db)
+	blockRepo := postgres.NewBlockRepository(db)
+	reportRepo := postgres.NewReportRepository(db)
+	photoRepo := postgres.NewPhotoRepository(db)
+	auditRepo := postgres.NewAuditRepository(db)
+	interestRepo := postgres.NewInterestRepository(db)
+	jobRepo := postgres.NewJobRepository(db)
+	quotaRepo := postgres.NewQuotaRepository(db)
+	unitOfWork := postgres.NewUnitOfWork(db)
+
+	// Durable background jobs, handlers are registered with the use cases below
+	jobQueue := jobs.NewQueue(jobRepo, cfg.Jobs.Workers, log)
 
 	// Initialize use cases
-	authUseCase := auth.NewVKAuthUseCase(
+	vkClient := vkapi.NewClient(cfg.VK.APIBaseURL)
+
+	photoUseCase := photo.NewPhotoUseCase(
+		photoRepo,
+		fileStorage,
+		log,
+	)
+
+	interestUseCase := interest.NewInterestUseCase(
+		interestRepo,
+		log,
+	)
+
+	// Synthetic (test) users only show up in development/test feeds
+	feedUseCase := feed.NewFeedUseCase(
 		userRepo,
 		profileRepo,
-		sessionRepo,
-		cfg.VK.SecretKey,
-		cfg.JWT.AccessSecret,
+		swipeRepo,
+		photoUseCase,
+		interestUseCase,
+		appCache,
+		!cfg.Server.IsDevelopment(),
+		log,
 	)
 
 	profileUseCase := profile.NewProfileUseCase(
 		profileRepo,
 		userRepo,
-		geminiClient,
+		blockRepo,
+		wingman,
+		feedUseCase,
+		vkClient,
+		photoUseCase,
+		interestUseCase,
+		log,
+	)
+
+	// Signup imports the VK name, city and avatar through the profile use case
+	authUseCase := auth.NewVKAuthUseCase(
+		userRepo,
+		profileRepo,
+		sessionRepo,
+		unitOfWork,
+		cfg.VK.SecretKey,
+		cfg.VK.AppID,
+		cfg.VK.LaunchParamsMaxAge,
+		tokenManager,
+		vkClient,
+		profileUseCase,
+		log,
 	)
 
 	bigFiveUseCase := bigfive.NewBigFiveUseCase(
 		bigFiveRepo,
 	)
 
-	feedUseCase := feed.NewFeedUseCase(
-		userRepo,
-		profileRepo,
-		swipeRepo,
+	notificationUseCase := notification.NewNotificationUseCase(
+		notificationRepo,
+		hub,
+		log,
 	)
 
 	swipeUseCase := swipe.NewSwipeUseCase(
 		swipeRepo,
+		matchRepo,
+		unitOfWork,
+		profileRepo,
+		userRepo,
+		blockRepo,
+		quotaRepo,
+		wingman,
+		hub,
+		notificationUseCase,
+		feedUseCase,
+		photoUseCase,
+		cfg.Swipe.UndoWindow,
+		swipe.DailyLimits{
+			Likes:      cfg.Swipe.DailyLikeLimit,
+			SuperLikes: cfg.Swipe.DailySuperLikeLimit,
+		},
+		log,
+	)
+	jobQueue.Register(swipe.JobLearnPreferences, swipeUseCase.LearnPreferencesJob)
+	jobQueue.Register(swipe.JobEnrichMatch, swipeUseCase.EnrichMatchJob)
+
+	matchUseCase := match.NewMatchUseCase(
 		matchRepo,
 		profileRepo,
 		userRepo,
-		geminiClient,
+		messageRepo,
+		photoUseCase,
+		encryptor,
+		log,
+	)
+
+	messageUseCase := message.NewMessageUseCase(
+		messageRepo,
+		matchRepo,
+		userRepo,
+		encryptor,
+		hub,
+		notificationUseCase,
+		log,
+	)
+
+	moderationUseCase := moderation.NewModerationUseCase(
+		blockRepo,
+		reportRepo,
+		userRepo,
+		matchRepo,
+		messageRepo,
+		unitOfWork,
+		feedUseCase,
+		log,
+	)
+
+	adminUseCase := admin.NewAdminUseCase(
+		userRepo,
+		profileRepo,
+		swipeRepo,
+		matchRepo,
+		reportRepo,
+		auditRepo,
+		unitOfWork,
+		notificationUseCase,
+		log,
 	)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authUseCase)
-	profileHandler := handler.NewProfileHandler(profileUseCase)
+	profileHandler := handler.NewProfileHandler(profileUseCase, log)
+	photoHandler := handler.NewPhotoHandler(photoUseCase, cfg.Storage.MaxUploadBytes)
+	interestHandler := handler.NewInterestHandler(interestUseCase)
 	bigFiveHandler := handler.NewBigFiveHandler(bigFiveUseCase)
 	feedHandler := handler.NewFeedHandler(feedUseCase)
 	swipeHandler := handler.NewSwipeHandler(swipeUseCase)
+	matchHandler := handler.NewMatchHandler(matchUseCase)
+	messageHandler := handler.NewMessageHandler(messageUseCase)
+	notificationHandler := handler.NewNotificationHandler(notificationUseCase)
+	moderationHandler := handler.NewModerationHandler(moderationUseCase)
+	adminHandler := handler.NewAdminHandler(adminUseCase)
+	wsHandler := handler.NewWSHandler(hub, messageUseCase, log)
+	healthHandler := handler.NewHealthHandler(checker, log)
 
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(authUseCase)
@@ -107,33 +315,89 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	router := http.NewRouter(
 		authHandler,
 		profileHandler,
+		photoHandler,
+		interestHandler,
 		bigFiveHandler,
 		feedHandler,
 		swipeHandler,
+		matchHandler,
+		messageHandler,
+		notificationHandler,
+		moderationHandler,
+		adminHandler,
+		wsHandler,
+		healthHandler,
 		authMiddleware,
+		&cfg.Server,
+		fileStorage,
+		log,
 	)
 
 	// Setup routes
 	ginRouter := router.Setup()
 
 	// Initialize server
-	srv := server.NewServer(&cfg.Server, ginRouter)
+	srv := server.NewServer(&cfg.Server, ginRouter, metrics.Handler(), log)
+
+	// Start background workers: real-time events, the job queue and periodic jobs
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	jobsDrained := make(chan struct{})
+	go func() {
+		defer close(jobsDrained)
+		jobQueue.Run(bgCtx)
+	}()
+	go func() {
+		if err := hub.Run(bgCtx); err != nil {
+			log.Error("real-time hub stopped", "error", err)
+		}
+	}()
+	go jobs.RunPeriodic(bgCtx, log, "session cleanup", sessionCleanupInterval, sessionRepo.DeleteExpired)
 
 	return &Container{
-		Config: cfg,
-		DB:     db,
-		Redis:  nil,
-		Server: srv,
-		Gemini: geminiClient,
+		Config:  cfg,
+		DB:      db,
+		Redis:   redisClient,
+		Server:  srv,
+		Wingman: wingman,
+		Hub:     hub,
+		Logger:  log,
+
+		stopBackground:  stopBackground,
+		jobsDrained:     jobsDrained,
+		shutdownTracing: shutdownTracing,
 	}, nil
 }
 
 // Close closes all connections
 func (c *Container) Close() error {
+	// Stop real-time hub and background jobs
+	if c.stopBackground != nil {
+		c.stopBackground()
+	}
+
+	// Let running jobs finish, the rest stay queued for the next start.
+	// A job still running after the timeout is retried once its lease expires.
+	if c.jobsDrained != nil {
+		select {
+		case <-c.jobsDrained:
+		case <-time.After(c.Config.Jobs.DrainTimeout):
+			c.Logger.Warn("background jobs did not finish in time", "timeout", c.Config.Jobs.DrainTimeout.String())
+		}
+	}
+
 	// Close Redis
 	if c.Redis != nil {
 		if err := c.Redis.Close(); err != nil {
-			fmt.Printf("Error closing Redis: %v\n", err)
+			c.Logger.Error("failed to close Redis", "error", err)
+		}
+	}
+
+	// Flush buffered spans
+	if c.shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+		defer cancel()
+		if err := c.shutdownTracing(ctx); err != nil {
+			c.Logger.Error("failed to flush traces", "error", err)
 		}
 	}
 
@@ -146,3 +410,21 @@ func (c *Container) Close() error {
 
 	return nil
 }
+
+// newAIWingman creates the AI provider selected in config, nil for none
+func newAIWingman(cfg *config.AIConfig, log *slog.Logger) (llm.AIWingman, error) {
+	switch cfg.Provider {
+	case llm.ProviderGemini:
+		client, err := gemini.NewGeminiClient(cfg.GeminiAPIKey, cfg.Model, log)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case llm.ProviderOpenAI:
+		return llm.NewOpenAIClient(cfg.BaseURL, cfg.APIKey, cfg.Model, log), nil
+	case llm.ProviderFake:
+		return llm.NewFakeWingman(), nil
+	default:
+		return nil, nil
+	}
+}
@@ -1,33 +1,62 @@
 package gemini
 
 import (
+	"log/slog"
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/llm"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/metrics"
 	"github.com/google/generative-ai-go/genai"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/option"
 )
 
+// DefaultModel is used when AI_MODEL is not set
+const DefaultModel = "gemini-2.0-flash-exp"
+
+var tracer = otel.Tracer("github.com/gdugdh24/mpit2026-backend/internal/infrastructure/gemini")
+
+var _ llm.AIWingman = (*GeminiClient)(nil)
+
 type GeminiClient struct {
 	client *genai.Client
-	model  *genai.GenerativeModel
+	// models has a model per operation, each one set up with its response schema
+	models    map[string]*genai.GenerativeModel
+	modelName string
+	log       *slog.Logger
 }
 
-func NewGeminiClient(apiKey string) (*GeminiClient, error) {
+func NewGeminiClient(apiKey, modelName string, log *slog.Logger) (*GeminiClient, error) {
+	if modelName == "" {
+		modelName = DefaultModel
+	}
+
 	ctx := context.Background()
 	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gemini client: %w", err)
 	}
 
-	model := client.GenerativeModel("gemini-2.0-flash-exp")
-	model.SetTemperature(0.7)
+	models := make(map[string]*genai.GenerativeModel, len(schemas))
+	for operation, schema := range schemas {
+		model := client.GenerativeModel(modelName)
+		model.SetTemperature(0.7)
+		model.ResponseMIMEType = "application/json"
+		model.ResponseSchema = schema
+		models[operation] = model
+	}
 
 	return &GeminiClient{
-		client: client,
-		model:  model,
+		client:    client,
+		models:    models,
+		modelName: modelName,
+		log:       log,
 	}, nil
 }
 
@@ -35,27 +64,46 @@ func (c *GeminiClient) Close() {
 	c.client.Close()
 }
 
-func (c *GeminiClient) GenerateMatchExplanation(ctx context.Context, user1Traits, user2Traits map[string]interface{}) (string, error) {
-	prompt := fmt.Sprintf(`
-		Analyze the compatibility of two users based on their traits.
-		User 1: %v
-		User 2: %v
-		
-		Task: Write a short, engaging explanation (1-2 sentences) of why they are a good match. 
-		Focus on complementarity (e.g., "Your calmness balances her energy").
-		Language: Russian.
-		Output: Just the explanation text.
-	`, user1Traits, user2Traits)
-
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
+// Operation names used as metric labels
+const (
+	opMatchExplanation = "match_explanation"
+	opIcebreakers      = "icebreakers"
+	opBio              = "bio"
+)
+
+// errNoContent is returned when the model answers without any candidates
+var errNoContent = fmt.Errorf("no content generated")
+
+// generate sends the prompt and returns the text of the first candidate,
+// recording the call count and latency per operation and a span per call
+func (c *GeminiClient) generate(ctx context.Context, operation, prompt string) (string, error) {
+	ctx, span := tracer.Start(ctx, "Gemini."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("gen_ai.system", "gemini"),
+			attribute.String("gen_ai.request.model", c.modelName),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	resp, err := c.models[operation].GenerateContent(ctx, genai.Text(prompt))
+	metrics.GeminiRequestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+
+	result := "ok"
 	if err != nil {
-		// Fallback to mock response if API is unavailable
-		fmt.Printf("⚠️  [AI Wingman] Gemini API unavailable, using fallback explanation\n")
-		return c.getMockExplanation(user1Traits, user2Traits), nil
+		result = "error"
+	}
+	metrics.GeminiRequests.WithLabelValues(operation, result).Inc()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "gemini request failed")
+		return "", err
 	}
 
 	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
-		return c.getMockExplanation(user1Traits, user2Traits), nil
+		span.SetStatus(codes.Error, errNoContent.Error())
+		return "", errNoContent
 	}
 
 	var sb strings.Builder
@@ -68,123 +116,34 @@ func (c *GeminiClient) GenerateMatchExplanation(ctx context.Context, user1Traits
 	return strings.TrimSpace(sb.String()), nil
 }
 
-func (c *GeminiClient) getMockExplanation(user1Traits, user2Traits map[string]interface{}) string {
-	// Extract names if available
-	name1 := "Вы"
-	name2 := "ваш матч"
-	if n, ok := user1Traits["Name"].(string); ok && n != "" {
-		name1 = n
-	}
-	if n, ok := user2Traits["Name"].(string); ok && n != "" {
-		name2 = n
-	}
+func (c *GeminiClient) GenerateMatchExplanation(ctx context.Context, user1Traits, user2Traits map[string]interface{}) (string, error) {
+	prompt := llm.MatchExplanationPrompt(user1Traits, user2Traits)
 
-	mockExplanations := []string{
-		fmt.Sprintf("Ваши общие интересы создают отличную основу для знакомства! %s и %s разделяют любовь к новым впечатлениям.", name1, name2),
-		fmt.Sprintf("Вы дополняете друг друга: спокойствие %s гармонирует с энергичностью %s.", name1, name2),
-		fmt.Sprintf("Ваши личности идеально сочетаются — %s и %s смогут найти общий язык!", name1, name2),
-		"Ваша совместимость по интересам и характеру впечатляет! Это может стать началом чего-то особенного.",
+	out, err := generateJSON(ctx, c, opMatchExplanation, prompt, validateExplanation)
+	if err != nil {
+		return "", err
 	}
-
-	// Return first mock explanation (or random in production)
-	return mockExplanations[0]
+	return strings.TrimSpace(out.Explanation), nil
 }
 
 func (c *GeminiClient) GenerateIcebreakers(ctx context.Context, user1Interests, user2Interests []string) ([]string, error) {
-	prompt := fmt.Sprintf(`
-		Suggest 3 engaging discussion topics for two people who have just matched on a dating app.
-		User 1 Interests: %v
-		User 2 Interests: %v
-		
-		Task: Suggest 3 distinct topics they could discuss right now.
-		Focus on shared interests or interesting contrasts.
-		Format: Return specific questions or themes like "Discuss the best sci-fi movies of 2024" or "Ask about his trip to Japan".
-		Language: Russian.
-		Output: JSON array of strings. Example: ["Discuss...", "Ask about..."]
-	`, user1Interests, user2Interests)
-
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
+	prompt := llm.IcebreakersPrompt(user1Interests, user2Interests)
+
+	icebreakers, err := generateJSON(ctx, c, opIcebreakers, prompt, llm.ValidateIcebreakers)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
-		return nil, fmt.Errorf("no content generated")
-	}
-
-	var sb strings.Builder
-	for _, part := range resp.Candidates[0].Content.Parts {
-		if txt, ok := part.(genai.Text); ok {
-			sb.WriteString(string(txt))
-		}
-	}
-
-	responseText := strings.TrimSpace(sb.String())
-	// Clean up markdown code blocks if present
-	responseText = strings.TrimPrefix(responseText, "```json")
-	responseText = strings.TrimPrefix(responseText, "```")
-	responseText = strings.TrimSuffix(responseText, "```")
-
-	var icebreakers []string
-	if err := json.Unmarshal([]byte(responseText), &icebreakers); err != nil {
-		// Fallback if JSON parsing fails - just return raw text split by newlines
-		lines := strings.Split(responseText, "\n")
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line != "" && !strings.HasPrefix(line, "[") && !strings.HasSuffix(line, "]") {
-				icebreakers = append(icebreakers, line)
-			}
-		}
-		if len(icebreakers) == 0 {
-			// If manual parsing also failed or it was valid JSON but we failed to parse it initially
-			return nil, fmt.Errorf("failed to parse icebreakers: %w", err)
-		}
-	}
-
-	return icebreakers, nil
+	return llm.TrimAll(icebreakers), nil
 }
 
 func (c *GeminiClient) GenerateBio(ctx context.Context, displayName string, interests []string, city string) (map[string]string, error) {
-	prompt := fmt.Sprintf(`
-		Generate 3 creative dating profile bios for a user.
-		Name: %s
-		Interests: %v
-		City: %s
-		
-		Task: Write 3 different bios styles:
-		1. "funny": Humorous and witty.
-		2. "mysterious": Intriguing and short.
-		3. "creative": Unique and descriptive.
-		
-		Language: Russian.
-		Output: JSON object with keys "funny", "mysterious", "creative". Example: {"funny": "...", "mysterious": "..."}
-	`, displayName, interests, city)
-
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
+	prompt := llm.BioPrompt(displayName, interests, city)
+
+	bios, err := generateJSON(ctx, c, opBio, prompt, llm.ValidateBios)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
-		return nil, fmt.Errorf("no content generated")
-	}
-
-	var sb strings.Builder
-	for _, part := range resp.Candidates[0].Content.Parts {
-		if txt, ok := part.(genai.Text); ok {
-			sb.WriteString(string(txt))
-		}
-	}
-
-	responseText := strings.TrimSpace(sb.String())
-	responseText = strings.TrimPrefix(responseText, "```json")
-	responseText = strings.TrimPrefix(responseText, "```")
-	responseText = strings.TrimSuffix(responseText, "```")
-
-	var bios map[string]string
-	if err := json.Unmarshal([]byte(responseText), &bios); err != nil {
-		return nil, fmt.Errorf("failed to parse bios: %w", err)
-	}
-
-	return bios, nil
+	// Only the known styles are returned, extra keys are dropped
+	return llm.KnownBios(bios), nil
 }
@@ -0,0 +1,124 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/llm"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/metrics"
+	"github.com/google/generative-ai-go/genai"
+)
+
+// maxRepairs is how many times an invalid answer is sent back to the model
+const maxRepairs = 1
+
+// Response schemas per operation, the model is constrained to emit JSON of this shape
+var schemas = map[string]*genai.Schema{
+	opMatchExplanation: {
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"explanation": {Type: genai.TypeString, Description: "1-2 sentences in Russian"},
+		},
+		Required: []string{"explanation"},
+	},
+	opIcebreakers: {
+		Type:        genai.TypeArray,
+		Description: "exactly 3 topics in Russian",
+		Items:       &genai.Schema{Type: genai.TypeString},
+	},
+	opBio: {
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			llm.BioFunny:      {Type: genai.TypeString},
+			llm.BioMysterious: {Type: genai.TypeString},
+			llm.BioCreative:   {Type: genai.TypeString},
+		},
+		Required: []string{llm.BioFunny, llm.BioMysterious, llm.BioCreative},
+	},
+}
+
+// APIError is returned when the Gemini call itself failed (network, quota,
+// timeout). Unlike an OutputError it is worth retrying later.
+type APIError struct {
+	Operation string
+	Err       error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gemini %s: %v", e.Operation, e.Err)
+}
+
+func (e *APIError) Unwrap() error { return e.Err }
+
+// OutputError is returned when the answer is still invalid after the repair
+// attempts. It wraps llm.ErrInvalidOutput.
+type OutputError struct {
+	Operation string
+	Attempts  int
+	// Reason is why the last answer was rejected
+	Reason string
+}
+
+func (e *OutputError) Error() string {
+	return fmt.Sprintf("gemini %s: invalid output after %d attempts: %s", e.Operation, e.Attempts, e.Reason)
+}
+
+func (e *OutputError) Unwrap() error { return llm.ErrInvalidOutput }
+
+// generateJSON asks the operation's model for JSON, decodes it into T and
+// validates it. A rejected answer is sent back together with the reason, so
+// the model can fix it, up to maxRepairs times.
+func generateJSON[T any](ctx context.Context, c *GeminiClient, operation, prompt string, validate func(T) error) (T, error) {
+	var zero T
+	var problem error
+
+	attemptPrompt := prompt
+	for attempt := 1; attempt <= maxRepairs+1; attempt++ {
+		text, err := c.generate(ctx, operation, attemptPrompt)
+		if err != nil && !errors.Is(err, errNoContent) {
+			return zero, &APIError{Operation: operation, Err: err}
+		}
+
+		var out T
+		if err == nil {
+			err = decode(text, &out, validate)
+		}
+		if err == nil {
+			return out, nil
+		}
+
+		problem = err
+		metrics.GeminiInvalidOutputs.WithLabelValues(operation).Inc()
+		c.log.WarnContext(ctx, "gemini returned invalid output",
+			"operation", operation, "attempt", attempt, "error", err)
+		attemptPrompt = repairPrompt(prompt, text, err)
+	}
+
+	return zero, &OutputError{Operation: operation, Attempts: maxRepairs + 1, Reason: problem.Error()}
+}
+
+func decode[T any](text string, out *T, validate func(T) error) error {
+	if err := json.Unmarshal([]byte(text), out); err != nil {
+		return fmt.Errorf("malformed JSON: %w", err)
+	}
+	return validate(*out)
+}
+
+func repairPrompt(prompt, answer string, problem error) string {
+	return fmt.Sprintf(`%s
+
+		Your previous answer was rejected: %s.
+		Previous answer: %s
+		Answer again, follow every instruction above and return only the JSON.
+	`, prompt, problem, answer)
+}
+
+type explanationOutput struct {
+	Explanation string `json:"explanation"`
+}
+
+func validateExplanation(out explanationOutput) error {
+	return llm.ValidateExplanation(out.Explanation)
+}
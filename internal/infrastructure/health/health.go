@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Dependency statuses
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check pings a dependency, a nil error means it is reachable
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs readiness checks of all dependencies
+type Checker struct {
+	checks  []namedCheck
+	timeout time.Duration
+}
+
+// NewChecker creates a checker, every check gets at most timeout
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+	}
+}
+
+// Add registers a dependency check, it must be called before serving traffic
+func (c *Checker) Add(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// DependencyStatus is the result of a single check
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the result of all checks
+type Report struct {
+	Ready        bool                        `json:"ready"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+// Check runs all checks in parallel, the report is ready if all of them pass
+func (c *Checker) Check(ctx context.Context) *Report {
+	report := &Report{
+		Ready:        true,
+		Dependencies: make(map[string]DependencyStatus, len(c.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range c.checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := nc.check(checkCtx)
+			status := DependencyStatus{
+				Status:    StatusUp,
+				LatencyMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				status.Status = StatusDown
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Dependencies[nc.name] = status
+			if err != nil {
+				report.Ready = false
+			}
+		}(nc)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// HTTPCheck expects a 2xx response to GET url, the check context bounds the request
+func HTTPCheck(url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
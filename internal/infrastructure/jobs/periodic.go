@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Func is a unit of periodic background work
+type Func func(ctx context.Context) error
+
+// RunPeriodic runs fn every interval until ctx is cancelled.
+// Errors are logged and don't stop the loop.
+func RunPeriodic(ctx context.Context, log *slog.Logger, name string, interval time.Duration, fn Func) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				log.WarnContext(ctx, "periodic job failed", "job", name, "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/metrics"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	// pollInterval is how long an idle worker waits before looking for due jobs again
+	pollInterval = time.Second
+	// jobTimeout bounds a single run of a job
+	jobTimeout = 2 * time.Minute
+	// lease is how long a claimed job stays locked, it must exceed jobTimeout
+	// so a running job is not picked up twice
+	lease = jobTimeout + time.Minute
+	// Retries back off exponentially from baseBackoff up to maxBackoff
+	baseBackoff = 5 * time.Second
+	maxBackoff  = 10 * time.Minute
+)
+
+var tracer = otel.Tracer("github.com/gdugdh24/mpit2026-backend/internal/infrastructure/jobs")
+
+// Handler processes one job. Returning an error retries the job later,
+// unless the error is Permanent.
+type Handler func(ctx context.Context, job *domain.Job) error
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error that retrying can't fix, such as a deleted
+// match, the job goes to the dead letters right away
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Queue runs jobs from the jobs table on a pool of workers. Jobs are claimed
+// with SELECT ... FOR UPDATE SKIP LOCKED, so any number of instances can
+// share the table, and are delivered at least once: handlers must tolerate
+// running again after a crash.
+type Queue struct {
+	repo     repository.JobRepository
+	workers  int
+	handlers map[string]Handler
+	log      *slog.Logger
+}
+
+func NewQueue(repo repository.JobRepository, workers int, log *slog.Logger) *Queue {
+	return &Queue{
+		repo:     repo,
+		workers:  max(workers, 1),
+		handlers: make(map[string]Handler),
+		log:      log,
+	}
+}
+
+// Register sets the handler of a job type, it must be called before Run
+func (q *Queue) Register(jobType string, handler Handler) {
+	q.handlers[jobType] = handler
+}
+
+// Run processes jobs until ctx is cancelled. It returns once the jobs in
+// progress have finished, they are not interrupted by the cancellation.
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < q.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		if q.processNext(ctx) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// processNext runs one due job, reporting whether there was one
+func (q *Queue) processNext(ctx context.Context) bool {
+	jobs, err := q.repo.Claim(ctx, 1, lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			q.log.WarnContext(ctx, "failed to claim jobs", "error", err)
+		}
+		return false
+	}
+	if len(jobs) == 0 {
+		return false
+	}
+
+	// A claimed job finishes even during shutdown, that is the drain
+	q.process(context.WithoutCancel(ctx), jobs[0])
+	return true
+}
+
+func (q *Queue) process(ctx context.Context, job *domain.Job) {
+	ctx, span := tracer.Start(ctx, "job "+job.Type)
+	span.SetAttributes(
+		attribute.Int64("job.id", job.ID),
+		attribute.String("job.type", job.Type),
+		attribute.Int("job.attempt", job.Attempts),
+	)
+	defer span.End()
+
+	log := q.log.With("job_id", job.ID, "job_type", job.Type, "attempt", job.Attempts)
+
+	err := q.run(ctx, job)
+	if err == nil {
+		metrics.Jobs.WithLabelValues(job.Type, "done").Inc()
+		if err := q.repo.Complete(ctx, job.ID, job.Attempts); err != nil {
+			q.logFinishError(ctx, log, "failed to complete job", err)
+		}
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, "job failed")
+
+	var permanent *permanentError
+	if errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts {
+		metrics.Jobs.WithLabelValues(job.Type, "dead").Inc()
+		log.ErrorContext(ctx, "job moved to dead letters", "error", err)
+		if err := q.repo.Bury(ctx, job.ID, job.Attempts, err.Error()); err != nil {
+			q.logFinishError(ctx, log, "failed to bury job", err)
+		}
+		return
+	}
+
+	delay := backoff(job.Attempts)
+	metrics.Jobs.WithLabelValues(job.Type, "retry").Inc()
+	log.WarnContext(ctx, "job failed, retrying", "retry_in", delay.String(), "error", err)
+	if err := q.repo.Retry(ctx, job.ID, job.Attempts, time.Now().Add(delay), err.Error()); err != nil {
+		q.logFinishError(ctx, log, "failed to reschedule job", err)
+	}
+}
+
+// logFinishError logs a failure to record the job's result. A lost lease is
+// expected when a job outlives it, another worker owns the job by then.
+func (q *Queue) logFinishError(ctx context.Context, log *slog.Logger, msg string, err error) {
+	if errors.Is(err, domain.ErrJobLeaseLost) {
+		log.WarnContext(ctx, msg, "error", err)
+		return
+	}
+	log.ErrorContext(ctx, msg, "error", err)
+}
+
+// run calls the job's handler with a timeout, turning panics into errors
+func (q *Queue) run(ctx context.Context, job *domain.Job) (err error) {
+	handler, ok := q.handlers[job.Type]
+	if !ok {
+		return Permanent(fmt.Errorf("no handler for job type %q", job.Type))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+// backoff doubles the delay with every attempt and adds up to 20% jitter,
+// so jobs that failed together don't retry together
+func backoff(attempt int) time.Duration {
+	delay := maxBackoff
+	if shift := max(attempt-1, 0); shift < 20 {
+		delay = min(baseBackoff<<shift, maxBackoff)
+	}
+	return delay + rand.N(delay/5+1)
+}
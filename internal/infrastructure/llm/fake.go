@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// FakeWingman builds texts from templates without calling any model. The same
+// input always gives the same output, which suits tests and offline development.
+type FakeWingman struct{}
+
+func NewFakeWingman() *FakeWingman {
+	return &FakeWingman{}
+}
+
+func (f *FakeWingman) GenerateMatchExplanation(_ context.Context, user1Traits, user2Traits map[string]interface{}) (string, error) {
+	name1 := traitString(user1Traits, "Name", "Вы")
+	name2 := traitString(user2Traits, "Name", "ваш матч")
+
+	shared := sharedInterests(traitStrings(user1Traits, "Interests"), traitStrings(user2Traits, "Interests"))
+	if len(shared) > 0 {
+		return fmt.Sprintf("%s и %s разделяют интерес к теме «%s» — отличная основа для знакомства!", name1, name2, shared[0]), nil
+	}
+	return fmt.Sprintf("%s и %s увлекаются разным, так что вам точно будет что рассказать друг другу.", name1, name2), nil
+}
+
+// GenerateIcebreakers always returns 3 topics: shared interests first, then
+// each user's own, then generic questions
+func (f *FakeWingman) GenerateIcebreakers(_ context.Context, user1Interests, user2Interests []string) ([]string, error) {
+	const count = 3
+
+	var icebreakers []string
+	shared := make(map[string]bool)
+	for _, interest := range sharedInterests(user1Interests, user2Interests) {
+		shared[strings.ToLower(interest)] = true
+		icebreakers = append(icebreakers, fmt.Sprintf("Обсудите, за что вы оба любите тему «%s»", interest))
+	}
+	for _, interest := range append(append([]string{}, user1Interests...), user2Interests...) {
+		if !shared[strings.ToLower(interest)] {
+			icebreakers = append(icebreakers, fmt.Sprintf("Спросите, как началось увлечение «%s»", interest))
+		}
+	}
+	icebreakers = append(icebreakers,
+		"Расскажите друг другу о самом запоминающемся путешествии",
+		"Спросите, как выглядит идеальный выходной",
+		"Обсудите, какую книгу или фильм стоит посоветовать другу",
+	)
+
+	return dedupe(icebreakers)[:count], nil
+}
+
+func (f *FakeWingman) GenerateBio(_ context.Context, displayName string, interests []string, city string) (map[string]string, error) {
+	topic := "новые впечатления"
+	if len(interests) > 0 {
+		topic = strings.Join(interests, ", ")
+	}
+	place := "в вашем городе"
+	if city != "" {
+		place = "в городе " + city
+	}
+
+	return map[string]string{
+		"funny":      fmt.Sprintf("Я %s, и мои увлечения — %s. Обещаю не рассказывать о них больше трех часов подряд.", displayName, topic),
+		"mysterious": fmt.Sprintf("%s. %s. Остальное — при встрече.", displayName, capitalize(topic)),
+		"creative":   fmt.Sprintf("%s. Интересы: %s. Ищу того, с кем можно открывать лучшие места %s.", displayName, topic, place),
+	}, nil
+}
+
+func capitalize(s string) string {
+	r := []rune(s)
+	if len(r) == 0 {
+		return s
+	}
+	return strings.ToUpper(string(r[0])) + string(r[1:])
+}
+
+func traitString(traits map[string]interface{}, key, fallback string) string {
+	if s, ok := traits[key].(string); ok && s != "" {
+		return s
+	}
+	return fallback
+}
+
+func traitStrings(traits map[string]interface{}, key string) []string {
+	switch v := traits[key].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// sharedInterests keeps the order of a, comparing case-insensitively
+func sharedInterests(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, interest := range b {
+		inB[strings.ToLower(interest)] = true
+	}
+
+	var shared []string
+	for _, interest := range a {
+		if inB[strings.ToLower(interest)] {
+			shared = append(shared, interest)
+		}
+	}
+	return shared
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
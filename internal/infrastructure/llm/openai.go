@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/gdugdh24/mpit2026-backend/internal/infrastructure/llm")
+
+// errNoChoices is returned when the server answers without any completion
+var errNoChoices = fmt.Errorf("%w: no completion choices returned", ErrInvalidOutput)
+
+// OpenAIClient talks to any server implementing the OpenAI chat completions
+// API: OpenAI itself, llama.cpp server, Ollama, vLLM and the like.
+type OpenAIClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+	log        *slog.Logger
+}
+
+// NewOpenAIClient creates a client for baseURL such as "http://localhost:11434/v1".
+// The API key is optional, local servers usually don't check it.
+func NewOpenAIClient(baseURL, apiKey, model string, log *slog.Logger) *OpenAIClient {
+	return &OpenAIClient{
+		httpClient: &http.Client{
+			// Local models on a CPU can take a while
+			Timeout: 60 * time.Second,
+		},
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		log:     log,
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// complete sends the prompt as a single user message and returns the answer
+func (c *OpenAIClient) complete(ctx context.Context, operation, prompt string) (_ string, err error) {
+	ctx, span := tracer.Start(ctx, "OpenAI."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("gen_ai.system", "openai"),
+			attribute.String("gen_ai.request.model", c.model),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "completion request failed")
+		}
+		span.End()
+	}()
+
+	body, err := json.Marshal(&chatRequest{
+		Model:       c.model,
+		Messages:    []chatMessage{{Role: "user", Content: prompt}},
+		Temperature: 0.7,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build completion request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call completion API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// The error body explains what is wrong, e.g. an unknown model
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("completion API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var chatResp chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
+		return "", fmt.Errorf("failed to decode completion response: %w", err)
+	}
+	if len(chatResp.Choices) == 0 {
+		return "", errNoChoices
+	}
+
+	return strings.TrimSpace(chatResp.Choices[0].Message.Content), nil
+}
+
+func (c *OpenAIClient) GenerateMatchExplanation(ctx context.Context, user1Traits, user2Traits map[string]interface{}) (string, error) {
+	return c.complete(ctx, "match_explanation", MatchExplanationPrompt(user1Traits, user2Traits))
+}
+
+func (c *OpenAIClient) GenerateIcebreakers(ctx context.Context, user1Interests, user2Interests []string) ([]string, error) {
+	text, err := c.complete(ctx, "icebreakers", IcebreakersPrompt(user1Interests, user2Interests))
+	if err != nil {
+		return nil, err
+	}
+
+	var icebreakers []string
+	if err := json.Unmarshal([]byte(StripCodeFence(text)), &icebreakers); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse icebreakers: %v", ErrInvalidOutput, err)
+	}
+	return icebreakers, nil
+}
+
+func (c *OpenAIClient) GenerateBio(ctx context.Context, displayName string, interests []string, city string) (map[string]string, error) {
+	text, err := c.complete(ctx, "bio", BioPrompt(displayName, interests, city))
+	if err != nil {
+		return nil, err
+	}
+
+	var bios map[string]string
+	if err := json.Unmarshal([]byte(StripCodeFence(text)), &bios); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse bios: %v", ErrInvalidOutput, err)
+	}
+	return bios, nil
+}
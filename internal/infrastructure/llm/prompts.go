@@ -0,0 +1,62 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MatchExplanationPrompt asks for a plain text explanation of a match
+func MatchExplanationPrompt(user1Traits, user2Traits map[string]interface{}) string {
+	return fmt.Sprintf(`
+		Analyze the compatibility of two users based on their traits.
+		User 1: %v
+		User 2: %v
+
+		Task: Write a short, engaging explanation (1-2 sentences) of why they are a good match.
+		Focus on complementarity (e.g., "Your calmness balances her energy").
+		Language: Russian.
+		Output: Just the explanation text.
+	`, user1Traits, user2Traits)
+}
+
+// IcebreakersPrompt asks for a JSON array of 3 conversation topics
+func IcebreakersPrompt(user1Interests, user2Interests []string) string {
+	return fmt.Sprintf(`
+		Suggest 3 engaging discussion topics for two people who have just matched on a dating app.
+		User 1 Interests: %v
+		User 2 Interests: %v
+
+		Task: Suggest 3 distinct topics they could discuss right now.
+		Focus on shared interests or interesting contrasts.
+		Format: Return specific questions or themes like "Discuss the best sci-fi movies of 2024" or "Ask about his trip to Japan".
+		Language: Russian.
+		Output: JSON array of strings. Example: ["Discuss...", "Ask about..."]
+	`, user1Interests, user2Interests)
+}
+
+// BioPrompt asks for a JSON object with a bio per style
+func BioPrompt(displayName string, interests []string, city string) string {
+	return fmt.Sprintf(`
+		Generate 3 creative dating profile bios for a user.
+		Name: %s
+		Interests: %v
+		City: %s
+
+		Task: Write 3 different bios styles:
+		1. "funny": Humorous and witty.
+		2. "mysterious": Intriguing and short.
+		3. "creative": Unique and descriptive.
+
+		Language: Russian.
+		Output: JSON object with keys "funny", "mysterious", "creative". Example: {"funny": "...", "mysterious": "..."}
+	`, displayName, interests, city)
+}
+
+// StripCodeFence removes the markdown code block models like to wrap JSON in
+func StripCodeFence(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	text = strings.TrimSuffix(text, "```")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,106 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits the output of every provider is validated against
+const (
+	IcebreakerCount      = 3
+	MaxIcebreakerLength  = 200
+	MaxExplanationLength = 300
+	// MaxBioLength matches the bio limit of PUT /profile/me
+	MaxBioLength = 500
+	// minCyrillicShare is the share of Cyrillic among the letters of a text
+	// for it to count as Russian. It is low on purpose: names, brands and
+	// interests like "Netflix" are often in Latin, an English answer has none.
+	minCyrillicShare = 0.3
+)
+
+// Bio styles, the keys of the GenerateBio result
+const (
+	BioFunny      = "funny"
+	BioMysterious = "mysterious"
+	BioCreative   = "creative"
+)
+
+// BioStyles lists the bio styles in the order they are shown
+var BioStyles = []string{BioFunny, BioMysterious, BioCreative}
+
+// The validators below return an error wrapping ErrInvalidOutput that says
+// what is wrong with the text, so it can also be shown to the model.
+
+// ValidateExplanation checks a match explanation
+func ValidateExplanation(explanation string) error {
+	return validateText("explanation", explanation, MaxExplanationLength)
+}
+
+// ValidateIcebreakers checks that there are exactly IcebreakerCount icebreakers
+func ValidateIcebreakers(icebreakers []string) error {
+	if len(icebreakers) != IcebreakerCount {
+		return fmt.Errorf("%w: expected %d icebreakers, got %d", ErrInvalidOutput, IcebreakerCount, len(icebreakers))
+	}
+	for i, icebreaker := range icebreakers {
+		if err := validateText(fmt.Sprintf("icebreaker %d", i+1), icebreaker, MaxIcebreakerLength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateBios checks the bio of every style, other keys are ignored
+func ValidateBios(bios map[string]string) error {
+	for _, style := range BioStyles {
+		if err := validateText(style+" bio", bios[style], MaxBioLength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateText checks that text is non-empty, fits maxLength characters and is in Russian
+func validateText(field, text string, maxLength int) error {
+	text = strings.TrimSpace(text)
+	switch {
+	case text == "":
+		return fmt.Errorf("%w: %s is empty", ErrInvalidOutput, field)
+	case utf8.RuneCountInString(text) > maxLength:
+		return fmt.Errorf("%w: %s is longer than %d characters", ErrInvalidOutput, field, maxLength)
+	case !isRussian(text):
+		return fmt.Errorf("%w: %s is not in Russian", ErrInvalidOutput, field)
+	}
+	return nil
+}
+
+func isRussian(text string) bool {
+	var letters, cyrillic int
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.Is(unicode.Cyrillic, r) {
+				cyrillic++
+			}
+		}
+	}
+	return letters > 0 && float64(cyrillic) >= float64(letters)*minCyrillicShare
+}
+
+// TrimAll trims the spaces around every text in place
+func TrimAll(texts []string) []string {
+	for i := range texts {
+		texts[i] = strings.TrimSpace(texts[i])
+	}
+	return texts
+}
+
+// KnownBios keeps the trimmed bios of the known styles, dropping other keys
+func KnownBios(bios map[string]string) map[string]string {
+	known := make(map[string]string, len(BioStyles))
+	for _, style := range BioStyles {
+		known[style] = strings.TrimSpace(bios[style])
+	}
+	return known
+}
@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateIcebreakers(t *testing.T) {
+	valid := "Спросите, как выглядит идеальный выходной"
+
+	tests := []struct {
+		name        string
+		icebreakers []string
+		wantErr     bool
+	}{
+		{name: "three in Russian", icebreakers: []string{valid, valid, "Обсудите любимый сериал на Netflix"}},
+		{name: "two", icebreakers: []string{valid, valid}, wantErr: true},
+		{name: "four", icebreakers: []string{valid, valid, valid, valid}, wantErr: true},
+		{name: "blank", icebreakers: []string{valid, valid, "  "}, wantErr: true},
+		{name: "English", icebreakers: []string{valid, valid, "Ask about their favourite trip"}, wantErr: true},
+		{name: "too long", icebreakers: []string{valid, valid, strings.Repeat("а", MaxIcebreakerLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIcebreakers(tt.icebreakers)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ValidateIcebreakers() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidOutput) {
+				t.Fatalf("error %v does not wrap ErrInvalidOutput", err)
+			}
+		})
+	}
+}
+
+func TestValidateBios(t *testing.T) {
+	bios := map[string]string{
+		BioFunny:      "Люблю кино и шутки",
+		BioMysterious: "Остальное — при встрече",
+		BioCreative:   "Ищу соавтора для прогулок",
+	}
+	if err := ValidateBios(bios); err != nil {
+		t.Fatalf("ValidateBios() = %v", err)
+	}
+
+	delete(bios, BioCreative)
+	if err := ValidateBios(bios); !errors.Is(err, ErrInvalidOutput) {
+		t.Fatalf("ValidateBios() without creative bio = %v, want ErrInvalidOutput", err)
+	}
+}
+
+// The templates are the fallback for invalid output, so they must pass the same checks
+func TestFakeWingmanOutputIsValid(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeWingman()
+	interests1 := []string{"кино", "Netflix", "походы"}
+	interests2 := []string{"кино", "шахматы"}
+
+	explanation, _ := fake.GenerateMatchExplanation(ctx,
+		map[string]interface{}{"Name": "Анна", "Interests": interests1},
+		map[string]interface{}{"Name": "Илья", "Interests": interests2})
+	if err := ValidateExplanation(explanation); err != nil {
+		t.Errorf("explanation: %v", err)
+	}
+
+	icebreakers, _ := fake.GenerateIcebreakers(ctx, interests1, interests2)
+	if err := ValidateIcebreakers(icebreakers); err != nil {
+		t.Errorf("icebreakers: %v", err)
+	}
+
+	bios, _ := fake.GenerateBio(ctx, "Анна", interests1, "Казань")
+	if err := ValidateBios(bios); err != nil {
+		t.Errorf("bios: %v", err)
+	}
+}
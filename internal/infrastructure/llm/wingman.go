@@ -0,0 +1,30 @@
+package llm
+
+import (
+	"context"
+	"errors"
+)
+
+// Providers selectable with AI_PROVIDER
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+	ProviderFake   = "fake"
+	ProviderNone   = "none"
+)
+
+// ErrInvalidOutput is wrapped by providers when the model answered but the
+// answer is unusable: malformed JSON, wrong shape or failed validation.
+// Asking again is unlikely to help, callers fall back to FakeWingman texts.
+var ErrInvalidOutput = errors.New("model output is invalid")
+
+// AIWingman writes the AI content of matches and profiles. All texts are in Russian.
+type AIWingman interface {
+	// GenerateMatchExplanation explains in 1-2 sentences why two users fit.
+	// Traits carry the user's "Name", "Interests", "Bio" and optional "BigFive".
+	GenerateMatchExplanation(ctx context.Context, user1Traits, user2Traits map[string]interface{}) (string, error)
+	// GenerateIcebreakers suggests topics to start the conversation with
+	GenerateIcebreakers(ctx context.Context, user1Interests, user2Interests []string) ([]string, error)
+	// GenerateBio writes bios keyed by style: funny, mysterious and creative
+	GenerateBio(ctx context.Context, displayName string, interests []string, city string) (map[string]string, error)
+}
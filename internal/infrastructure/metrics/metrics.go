@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "dating"
+
+// Registry holds all application metrics. A dedicated registry keeps
+// metrics of third-party libraries out of /metrics.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+// HTTP metrics
+var (
+	// HTTPRequestDuration is labelled with the route template, not the
+	// raw path, so IDs in URLs don't blow up the number of series
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// Gemini metrics
+var (
+	GeminiRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "gemini",
+		Name:      "requests_total",
+		Help:      "Gemini API calls by operation and result (ok, error).",
+	}, []string{"operation", "result"})
+
+	GeminiRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "gemini",
+		Name:      "request_duration_seconds",
+		Help:      "Gemini API call latency by operation.",
+		Buckets:   []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32},
+	}, []string{"operation"})
+
+	// GeminiInvalidOutputs counts answers rejected by validation, each one
+	// is either repaired by asking again or fails the call
+	GeminiInvalidOutputs = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "gemini",
+		Name:      "invalid_outputs_total",
+		Help:      "Gemini answers that failed schema or content validation.",
+	}, []string{"operation"})
+)
+
+// AI wingman metrics, independent of the provider
+var (
+	AIFallbacks = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ai",
+		Name:      "fallbacks_total",
+		Help:      "AI texts replaced by template fallbacks because the provider failed.",
+	}, []string{"operation"})
+)
+
+// Product metrics
+var (
+	Swipes = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "swipes_total",
+		Help:      "Swipes by direction (left, right, super).",
+	}, []string{"direction"})
+
+	Likes = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "likes_total",
+		Help:      "Likes including super likes.",
+	})
+
+	Matches = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "matches_total",
+		Help:      "Matches created.",
+	})
+
+	Messages = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_total",
+		Help:      "Chat messages sent.",
+	})
+
+	FeedCandidatePool = factory.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "feed",
+		Name:      "candidate_pool_size",
+		Help:      "Candidates returned by the feed query per ranking.",
+		Buckets:   []float64{0, 1, 5, 10, 25, 50, 100, 250},
+	})
+)
+
+// Background job metrics
+var (
+	Jobs = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "jobs",
+		Name:      "processed_total",
+		Help:      "Background job runs by type and result (done, retry, dead).",
+	}, []string{"type", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// RegisterDB exports the connection pool stats of db (sql.DB.Stats)
+func RegisterDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the registry in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
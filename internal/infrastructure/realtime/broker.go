@@ -0,0 +1,24 @@
+package realtime
+
+import (
+	"context"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+// Publisher pushes events to all connections of a user
+type Publisher interface {
+	Publish(ctx context.Context, userID int, event *domain.Event) error
+}
+
+// DeliverFunc receives an encoded event addressed to a user
+type DeliverFunc func(userID int, data []byte)
+
+// Broker carries encoded events between server instances.
+// Every instance runs one subscription and delivers events to its own
+// local connections, so a user can be connected to any node.
+type Broker interface {
+	Publish(ctx context.Context, userID int, data []byte) error
+	// Run blocks and calls deliver for every published event until ctx is done
+	Run(ctx context.Context, deliver DeliverFunc) error
+}
@@ -0,0 +1,109 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 4096
+	sendBufferSize = 64
+)
+
+// ClientMessage is a message sent by a client over the socket
+type ClientMessage struct {
+	Type    string `json:"type"`
+	MatchID int    `json:"match_id"`
+}
+
+// MessageHandler handles messages sent by a client
+type MessageHandler func(ctx context.Context, userID int, msg *ClientMessage)
+
+// Client is a single WebSocket connection of a user
+type Client struct {
+	hub       *Hub
+	conn      *websocket.Conn
+	userID    int
+	send      chan []byte
+	onMessage MessageHandler
+}
+
+// Serve registers the connection and blocks until it is closed
+func (h *Hub) Serve(ctx context.Context, conn *websocket.Conn, userID int, onMessage MessageHandler) {
+	c := &Client{
+		hub:       h,
+		conn:      conn,
+		userID:    userID,
+		send:      make(chan []byte, sendBufferSize),
+		onMessage: onMessage,
+	}
+
+	h.register(c)
+	go c.writePump()
+	c.readPump(ctx)
+}
+
+// readPump reads client messages until the connection fails
+func (c *Client) readPump(ctx context.Context) {
+	defer func() {
+		c.hub.unregister(c)
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+
+		if c.onMessage != nil {
+			c.onMessage(ctx, c.userID, &msg)
+		}
+	}
+}
+
+// writePump writes queued events and keeps the connection alive with pings
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case data, ok := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				// Hub closed the channel
+				_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
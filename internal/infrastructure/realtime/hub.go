@@ -0,0 +1,84 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+// Hub tracks local WebSocket connections and delivers broker events to them.
+// A user may have many connections (devices, tabs) on any instance.
+type Hub struct {
+	broker  Broker
+	mu      sync.RWMutex
+	clients map[int]map[*Client]struct{}
+}
+
+func NewHub(broker Broker) *Hub {
+	return &Hub{
+		broker:  broker,
+		clients: make(map[int]map[*Client]struct{}),
+	}
+}
+
+// Run consumes broker events until ctx is done
+func (h *Hub) Run(ctx context.Context) error {
+	return h.broker.Run(ctx, h.deliver)
+}
+
+// Publish sends an event to every connection of the user across instances
+func (h *Hub) Publish(ctx context.Context, userID int, event *domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	return h.broker.Publish(ctx, userID, data)
+}
+
+// ConnectionCount returns the number of local connections of the user
+func (h *Hub) ConnectionCount(userID int) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients[userID])
+}
+
+func (h *Hub) register(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.clients[c.userID] == nil {
+		h.clients[c.userID] = make(map[*Client]struct{})
+	}
+	h.clients[c.userID][c] = struct{}{}
+}
+
+func (h *Hub) unregister(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	conns := h.clients[c.userID]
+	if _, ok := conns[c]; !ok {
+		return
+	}
+	delete(conns, c)
+	if len(conns) == 0 {
+		delete(h.clients, c.userID)
+	}
+	close(c.send)
+}
+
+func (h *Hub) deliver(userID int, data []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for c := range h.clients[userID] {
+		select {
+		case c.send <- data:
+		default:
+			// Slow client: drop the event rather than block the hub
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBroker is an in-process broker for single-node and test runs
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	handlers map[int]DeliverFunc
+	nextID   int
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		handlers: make(map[int]DeliverFunc),
+	}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, userID int, data []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, deliver := range b.handlers {
+		deliver(userID, data)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Run(ctx context.Context, deliver DeliverFunc) error {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = deliver
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.handlers, id)
+	b.mu.Unlock()
+	return nil
+}
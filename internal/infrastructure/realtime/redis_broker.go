@@ -0,0 +1,58 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisChannelPrefix = "realtime:user:"
+
+// RedisBroker fans events out across server instances via Redis pub/sub.
+// Events are published to a per-user channel and every instance listens
+// with a single pattern subscription.
+type RedisBroker struct {
+	client *redis.Client
+}
+
+func NewRedisBroker(client *redis.Client) *RedisBroker {
+	return &RedisBroker{client: client}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, userID int, data []byte) error {
+	channel := redisChannelPrefix + strconv.Itoa(userID)
+	if err := b.client.Publish(ctx, channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish to redis: %w", err)
+	}
+	return nil
+}
+
+func (b *RedisBroker) Run(ctx context.Context, deliver DeliverFunc) error {
+	pubsub := b.client.PSubscribe(ctx, redisChannelPrefix+"*")
+	defer pubsub.Close()
+
+	// Wait for subscription confirmation
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to redis: %w", err)
+	}
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			userID, err := strconv.Atoi(strings.TrimPrefix(msg.Channel, redisChannelPrefix))
+			if err != nil {
+				continue
+			}
+			deliver(userID, []byte(msg.Payload))
+		}
+	}
+}
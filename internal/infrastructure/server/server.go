@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -13,12 +15,16 @@ import (
 // Server represents HTTP server
 type Server struct {
 	httpServer *http.Server
-	config     *config.ServerConfig
+	// metricsServer serves /metrics on the internal port, nil when disabled
+	metricsServer *http.Server
+	config        *config.ServerConfig
+	log           *slog.Logger
 }
 
-// NewServer creates a new HTTP server
-func NewServer(cfg *config.ServerConfig, router *gin.Engine) *Server {
-	return &Server{
+// NewServer creates a new HTTP server. Metrics are kept off the public router
+// and served on cfg.MetricsPort, which only the scraper should reach.
+func NewServer(cfg *config.ServerConfig, router *gin.Engine, metricsHandler http.Handler, log *slog.Logger) *Server {
+	s := &Server{
 		httpServer: &http.Server{
 			Addr:           fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
 			Handler:        router,
@@ -27,23 +33,54 @@ func NewServer(cfg *config.ServerConfig, router *gin.Engine) *Server {
 			MaxHeaderBytes: 1 << 20, // 1 MB
 		},
 		config: cfg,
+		log:    log,
 	}
+
+	if cfg.MetricsPort != 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metricsHandler)
+		s.metricsServer = &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", cfg.Host, cfg.MetricsPort),
+			Handler:           mux,
+			ReadHeaderTimeout: cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+		}
+	}
+
+	return s
 }
 
-// Start starts the HTTP server
+// Start starts the HTTP server and the metrics listener. It returns when
+// either of them stops.
 func (s *Server) Start() error {
-	fmt.Printf("Starting server on %s:%d\n", s.config.Host, s.config.Port)
+	errs := make(chan error, 2)
 
-	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		return fmt.Errorf("failed to start server: %w", err)
+	if s.metricsServer != nil {
+		go func() {
+			s.log.Info("starting metrics server", "addr", s.metricsServer.Addr)
+			if err := s.metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("failed to start metrics server: %w", err)
+				return
+			}
+			errs <- nil
+		}()
 	}
 
-	return nil
+	go func() {
+		s.log.Info("starting server", "addr", s.httpServer.Addr)
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs <- fmt.Errorf("failed to start server: %w", err)
+			return
+		}
+		errs <- nil
+	}()
+
+	return <-errs
 }
 
 // Shutdown gracefully shuts down the server
 func (s *Server) Shutdown(ctx context.Context) error {
-	fmt.Println("Shutting down server...")
+	s.log.Info("shutting down server")
 
 	shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -52,6 +89,12 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		return fmt.Errorf("server shutdown failed: %w", err)
 	}
 
-	fmt.Println("Server stopped")
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("metrics server shutdown failed: %w", err)
+		}
+	}
+
+	s.log.Info("server stopped")
 	return nil
 }
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/config"
+)
+
+// ErrNotFound is returned when a key does not exist
+var ErrNotFound = errors.New("object not found")
+
+// Storage keeps uploaded files. Keys are slash-separated paths such as
+// "photos/12/ab34.jpg", drivers map them to their own layout.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL the file is served under
+	URL(key string) string
+}
+
+// New creates the driver selected by STORAGE_TYPE
+func New(cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Type {
+	case "", "local":
+		local, err := NewLocalStorage(cfg.Path, cfg.PublicURL)
+		if err != nil {
+			return nil, err
+		}
+		return local, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage type %q", cfg.Type)
+	}
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+// AuditFilter narrows the audit log, zero values match everything
+type AuditFilter struct {
+	ActorID    *int
+	TargetType string
+	TargetID   *int
+	Limit      int
+	Offset     int
+}
+
+// AuditRepository is append-only, the table rejects updates and deletes
+type AuditRepository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	List(ctx context.Context, filter AuditFilter) ([]*domain.AuditEntry, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+type BlockRepository interface {
+	// Create is idempotent, blocking the same user twice keeps the first block
+	Create(ctx context.Context, block *domain.Block) error
+	// IsBlocked reports whether either user blocked the other
+	IsBlocked(ctx context.Context, user1ID, user2ID int) (bool, error)
+}
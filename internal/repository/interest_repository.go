@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+// InterestRepository reads the interest catalog
+type InterestRepository interface {
+	// List returns all interests ordered by category and position
+	List(ctx context.Context) ([]*domain.Interest, error)
+}
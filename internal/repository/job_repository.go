@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+type JobRepository interface {
+	Enqueue(ctx context.Context, job *domain.Job) error
+	// Claim locks up to limit due jobs for lease, jobs whose lease expired
+	// are claimed again. Each claim counts as an attempt, jobs whose lease
+	// expired on the last attempt are buried instead.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*domain.Job, error)
+	// Complete deletes a finished job. Complete, Retry and Bury only act on
+	// the claim of the given attempt and return domain.ErrJobLeaseLost once
+	// the lease expired and the job was claimed again or buried.
+	Complete(ctx context.Context, id int64, attempt int) error
+	// Retry releases the job to run again at runAt
+	Retry(ctx context.Context, id int64, attempt int, runAt time.Time, lastError string) error
+	// Bury moves the job to the dead letters
+	Bury(ctx context.Context, id int64, attempt int, lastError string) error
+}
@@ -8,11 +8,20 @@ import (
 
 type MatchRepository interface {
 	Create(ctx context.Context, match *domain.Match) error
+	// Upsert creates an active match, or reactivates the one swiperID deactivated
+	// with an undo. Any other existing match is returned as is, so a match a
+	// user ended stays inactive.
+	Upsert(ctx context.Context, match *domain.Match, swiperID int) error
 	GetByID(ctx context.Context, id int) (*domain.Match, error)
 	GetByUsers(ctx context.Context, user1ID, user2ID int) (*domain.Match, error)
 	GetUserMatches(ctx context.Context, userID int, limit, offset int) ([]*domain.Match, error)
-	GetActiveMatches(ctx context.Context, userID int) ([]*domain.Match, error)
+	GetActiveMatches(ctx context.Context, userID int, limit, offset int) ([]*domain.Match, error)
+	CountActiveMatches(ctx context.Context, userID int) (int, error)
 	UpdateStatus(ctx context.Context, id int, isActive bool) error
+	// DeactivateByUndo deactivates a match because swiperID undid their like
+	DeactivateByUndo(ctx context.Context, id, swiperID int) error
+	// DeactivateByUser ends all active matches of the user, e.g. on a ban
+	DeactivateByUser(ctx context.Context, userID int) (int, error)
 	Delete(ctx context.Context, id int) error
-	UpdateAIFields(ctx context.Context, matchID int, explanation string, icebreakers []string) error
+	UpdateAIFields(ctx context.Context, matchID int, explanation string, icebreakers []string, fallback bool) error
 }
@@ -9,8 +9,12 @@ import (
 type MessageRepository interface {
 	Create(ctx context.Context, message *domain.Message) error
 	GetByID(ctx context.Context, id int) (*domain.Message, error)
-	GetMatchMessages(ctx context.Context, matchID int, limit, offset int) ([]*domain.Message, error)
+	// GetMatchMessagesBefore and GetMatchMessagesAfter return the messages
+	// viewerID may see: messages of a shadow-banned sender only to the sender
+	GetMatchMessagesBefore(ctx context.Context, matchID, viewerID int, beforeID int, limit int) ([]*domain.Message, error)
+	GetMatchMessagesAfter(ctx context.Context, matchID, viewerID int, afterID int, limit int) ([]*domain.Message, error)
 	MarkAsRead(ctx context.Context, messageID int) error
+	MarkMatchAsRead(ctx context.Context, matchID, readerID int) (int, error)
 	GetUnreadCount(ctx context.Context, userID int) (int, error)
 	Delete(ctx context.Context, id int) error
 }
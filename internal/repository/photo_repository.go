@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+// PhotoRepository keeps positions of a user's photos contiguous from 0
+// and exactly one of them primary
+type PhotoRepository interface {
+	// Create appends the photo after the user's last one, the first photo
+	// becomes primary. Returns ErrPhotoLimitReached when the user already has
+	// MaxPhotosPerUser photos and ErrPhotoAlreadyImported when a photo with
+	// the same SourceURL exists.
+	Create(ctx context.Context, photo *domain.Photo) error
+	GetByID(ctx context.Context, id int) (*domain.Photo, error)
+	GetBySourceURL(ctx context.Context, userID int, sourceURL string) (*domain.Photo, error)
+	GetByUserID(ctx context.Context, userID int) ([]*domain.Photo, error)
+	// GetByUserIDs returns photos of several users ordered by user and position
+	GetByUserIDs(ctx context.Context, userIDs []int) ([]*domain.Photo, error)
+	// Delete removes the photo and promotes the next one if it was primary
+	Delete(ctx context.Context, userID, id int) error
+	SetPrimary(ctx context.Context, userID, id int) error
+	// Move puts the photo at the position, shifting the photos in between
+	Move(ctx context.Context, userID, id, position int) error
+}
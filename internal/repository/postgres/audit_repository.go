@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+type auditRepository struct {
+	db dbtx
+}
+
+func NewAuditRepository(db *sqlx.DB) repository.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	ctx, span := startSpan(ctx, "AuditRepository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO audit_log (actor_id, actor_role, action, target_type, target_id, details, ip_address)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	details := entry.Details
+	if len(details) == 0 {
+		details = []byte("{}")
+	}
+	return r.db.QueryRowContext(
+		ctx, query,
+		entry.ActorID, entry.ActorRole, entry.Action, entry.TargetType, entry.TargetID,
+		[]byte(details), entry.IPAddress,
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+func (r *auditRepository) List(ctx context.Context, filter repository.AuditFilter) ([]*domain.AuditEntry, error) {
+	ctx, span := startSpan(ctx, "AuditRepository.List")
+	defer span.End()
+
+	query := `SELECT * FROM audit_log WHERE 1=1`
+	args := []interface{}{}
+	argCount := 1
+
+	if filter.ActorID != nil {
+		query += fmt.Sprintf(" AND actor_id = $%d", argCount)
+		args = append(args, *filter.ActorID)
+		argCount++
+	}
+	if filter.TargetType != "" {
+		query += fmt.Sprintf(" AND target_type = $%d", argCount)
+		args = append(args, filter.TargetType)
+		argCount++
+	}
+	if filter.TargetID != nil {
+		query += fmt.Sprintf(" AND target_id = $%d", argCount)
+		args = append(args, *filter.TargetID)
+		argCount++
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", argCount, argCount+1)
+	args = append(args, filter.Limit, filter.Offset)
+
+	var entries []*domain.AuditEntry
+	err := r.db.SelectContext(ctx, &entries, query, args...)
+	return entries, err
+}
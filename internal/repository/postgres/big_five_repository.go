@@ -19,6 +19,9 @@ func NewBigFiveRepository(db *sqlx.DB) repository.BigFiveRepository {
 }
 
 func (r *bigFiveRepository) Create(ctx context.Context, result *domain.BigFiveResult) error {
+	ctx, span := startSpan(ctx, "BigFiveRepository.Create")
+	defer span.End()
+
 	query := `
 		INSERT INTO big_five_results (
 			user_id, openness, conscientiousness, extraversion,
@@ -36,6 +39,9 @@ func (r *bigFiveRepository) Create(ctx context.Context, result *domain.BigFiveRe
 }
 
 func (r *bigFiveRepository) GetByID(ctx context.Context, id int) (*domain.BigFiveResult, error) {
+	ctx, span := startSpan(ctx, "BigFiveRepository.GetByID")
+	defer span.End()
+
 	var result domain.BigFiveResult
 	query := `SELECT * FROM big_five_results WHERE id = $1`
 	err := r.db.GetContext(ctx, &result, query, id)
@@ -49,6 +55,9 @@ func (r *bigFiveRepository) GetByID(ctx context.Context, id int) (*domain.BigFiv
 }
 
 func (r *bigFiveRepository) GetByUserID(ctx context.Context, userID int) (*domain.BigFiveResult, error) {
+	ctx, span := startSpan(ctx, "BigFiveRepository.GetByUserID")
+	defer span.End()
+
 	var result domain.BigFiveResult
 	query := `SELECT * FROM big_five_results WHERE user_id = $1`
 	err := r.db.GetContext(ctx, &result, query, userID)
@@ -62,6 +71,9 @@ func (r *bigFiveRepository) GetByUserID(ctx context.Context, userID int) (*domai
 }
 
 func (r *bigFiveRepository) Update(ctx context.Context, result *domain.BigFiveResult) error {
+	ctx, span := startSpan(ctx, "BigFiveRepository.Update")
+	defer span.End()
+
 	query := `
 		UPDATE big_five_results
 		SET openness = $1, conscientiousness = $2, extraversion = $3,
@@ -79,6 +91,9 @@ func (r *bigFiveRepository) Update(ctx context.Context, result *domain.BigFiveRe
 }
 
 func (r *bigFiveRepository) Delete(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "BigFiveRepository.Delete")
+	defer span.End()
+
 	query := `DELETE FROM big_five_results WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+type blockRepository struct {
+	db dbtx
+}
+
+func NewBlockRepository(db *sqlx.DB) repository.BlockRepository {
+	return &blockRepository{db: db}
+}
+
+func (r *blockRepository) Create(ctx context.Context, block *domain.Block) error {
+	ctx, span := startSpan(ctx, "BlockRepository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT ON CONSTRAINT unique_block DO NOTHING
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRowContext(ctx, query, block.BlockerID, block.BlockedID).
+		Scan(&block.ID, &block.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Already blocked, load the existing row
+		query = `SELECT id, created_at FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`
+		return r.db.QueryRowContext(ctx, query, block.BlockerID, block.BlockedID).
+			Scan(&block.ID, &block.CreatedAt)
+	}
+	return err
+}
+
+func (r *blockRepository) IsBlocked(ctx context.Context, user1ID, user2ID int) (bool, error) {
+	ctx, span := startSpan(ctx, "BlockRepository.IsBlocked")
+	defer span.End()
+
+	var blocked bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+	err := r.db.GetContext(ctx, &blocked, query, user1ID, user2ID)
+	return blocked, err
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type interestRepository struct {
+	db *sqlx.DB
+}
+
+func NewInterestRepository(db *sqlx.DB) repository.InterestRepository {
+	return &interestRepository{db: db}
+}
+
+func (r *interestRepository) List(ctx context.Context) ([]*domain.Interest, error) {
+	ctx, span := startSpan(ctx, "InterestRepository.List")
+	defer span.End()
+
+	query := `
+		SELECT i.slug, i.name_ru, i.name_en, i.category_slug, c.name_ru, i.synonyms
+		FROM interests i
+		JOIN interest_categories c ON c.slug = i.category_slug
+		ORDER BY c.position, i.position, i.slug
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var interests []*domain.Interest
+	for rows.Next() {
+		interest := &domain.Interest{}
+		if err := rows.Scan(
+			&interest.Slug, &interest.Name, &interest.NameEn,
+			&interest.Category, &interest.CategoryName, pq.Array(&interest.Synonyms),
+		); err != nil {
+			return nil, err
+		}
+		interests = append(interests, interest)
+	}
+
+	return interests, rows.Err()
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+type jobRepository struct {
+	db dbtx
+}
+
+func NewJobRepository(db *sqlx.DB) repository.JobRepository {
+	return &jobRepository{db: db}
+}
+
+func (r *jobRepository) Enqueue(ctx context.Context, job *domain.Job) error {
+	ctx, span := startSpan(ctx, "JobRepository.Enqueue")
+	defer span.End()
+
+	query := `
+		INSERT INTO jobs (type, payload, max_attempts, run_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at, updated_at
+	`
+	payload := job.Payload
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+	return r.db.QueryRowContext(
+		ctx, query,
+		job.Type, []byte(payload), job.MaxAttempts, job.RunAt,
+	).Scan(&job.ID, &job.Status, &job.CreatedAt, &job.UpdatedAt)
+}
+
+func (r *jobRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*domain.Job, error) {
+	ctx, span := startSpan(ctx, "JobRepository.Claim")
+	defer span.End()
+
+	// SKIP LOCKED lets every worker take different rows without waiting.
+	// A job whose worker died on the last attempt is buried, not run again.
+	query := `
+		WITH buried AS (
+			UPDATE jobs
+			SET status = 'dead',
+			    locked_until = NULL,
+			    last_error = 'lease expired on the last attempt',
+			    updated_at = NOW()
+			WHERE id IN (
+				SELECT id FROM jobs
+				WHERE status = 'running' AND locked_until < NOW() AND attempts >= max_attempts
+				FOR UPDATE SKIP LOCKED
+			)
+		)
+		UPDATE jobs
+		SET status = 'running',
+		    attempts = attempts + 1,
+		    locked_until = NOW() + $2 * INTERVAL '1 second',
+		    updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE ((status = 'pending' AND run_at <= NOW())
+			   OR (status = 'running' AND locked_until < NOW()))
+			  AND attempts < max_attempts
+			ORDER BY run_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`
+	var jobs []*domain.Job
+	err := r.db.SelectContext(ctx, &jobs, query, limit, lease.Seconds())
+	return jobs, err
+}
+
+func (r *jobRepository) Complete(ctx context.Context, id int64, attempt int) error {
+	ctx, span := startSpan(ctx, "JobRepository.Complete")
+	defer span.End()
+
+	query := `DELETE FROM jobs WHERE id = $1 AND status = 'running' AND attempts = $2`
+	result, err := r.db.ExecContext(ctx, query, id, attempt)
+	return claimResult(result, err)
+}
+
+func (r *jobRepository) Retry(ctx context.Context, id int64, attempt int, runAt time.Time, lastError string) error {
+	ctx, span := startSpan(ctx, "JobRepository.Retry")
+	defer span.End()
+
+	query := `
+		UPDATE jobs
+		SET status = 'pending', run_at = $3, locked_until = NULL, last_error = $4, updated_at = NOW()
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+	`
+	result, err := r.db.ExecContext(ctx, query, id, attempt, runAt, lastError)
+	return claimResult(result, err)
+}
+
+func (r *jobRepository) Bury(ctx context.Context, id int64, attempt int, lastError string) error {
+	ctx, span := startSpan(ctx, "JobRepository.Bury")
+	defer span.End()
+
+	query := `
+		UPDATE jobs
+		SET status = 'dead', locked_until = NULL, last_error = $3, updated_at = NOW()
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+	`
+	result, err := r.db.ExecContext(ctx, query, id, attempt, lastError)
+	return claimResult(result, err)
+}
+
+// claimResult reports ErrJobLeaseLost when the claim matched no row
+func claimResult(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrJobLeaseLost
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/testutil/pgtest"
+)
+
+func TestJobLeaseExpiry(t *testing.T) {
+	db := pgtest.Open(t)
+	ctx := context.Background()
+	jobs := NewJobRepository(db)
+
+	// enqueue adds a job due long ago, so Claim with limit 1 takes it first
+	enqueue := func(maxAttempts int) *domain.Job {
+		t.Helper()
+		job := &domain.Job{Type: "test.lease", MaxAttempts: maxAttempts, RunAt: time.Unix(0, 0)}
+		if err := jobs.Enqueue(ctx, job); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+		t.Cleanup(func() {
+			if _, err := db.ExecContext(context.Background(), `DELETE FROM jobs WHERE id = $1`, job.ID); err != nil {
+				t.Errorf("delete job %d: %v", job.ID, err)
+			}
+		})
+		return job
+	}
+	claim := func() *domain.Job {
+		t.Helper()
+		claimed, err := jobs.Claim(ctx, 1, time.Minute)
+		if err != nil {
+			t.Fatalf("claim: %v", err)
+		}
+		if len(claimed) == 0 {
+			return nil
+		}
+		return claimed[0]
+	}
+	expireLease := func(id int64) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, `UPDATE jobs SET locked_until = NOW() - INTERVAL '1 second' WHERE id = $1`, id); err != nil {
+			t.Fatalf("expire lease: %v", err)
+		}
+	}
+	status := func(id int64) domain.JobStatus {
+		t.Helper()
+		var s domain.JobStatus
+		if err := db.GetContext(ctx, &s, `SELECT status FROM jobs WHERE id = $1`, id); err != nil {
+			t.Fatalf("get status: %v", err)
+		}
+		return s
+	}
+
+	t.Run("stale claim cannot finish the job", func(t *testing.T) {
+		job := enqueue(2)
+		if first := claim(); first == nil || first.ID != job.ID || first.Attempts != 1 {
+			t.Fatalf("first claim = %+v, want job %d attempt 1", first, job.ID)
+		}
+		expireLease(job.ID)
+		if second := claim(); second == nil || second.ID != job.ID || second.Attempts != 2 {
+			t.Fatalf("second claim = %+v, want job %d attempt 2", second, job.ID)
+		}
+
+		if err := jobs.Complete(ctx, job.ID, 1); !errors.Is(err, domain.ErrJobLeaseLost) {
+			t.Fatalf("complete of stale claim = %v, want ErrJobLeaseLost", err)
+		}
+		if err := jobs.Retry(ctx, job.ID, 1, time.Now(), "stale"); !errors.Is(err, domain.ErrJobLeaseLost) {
+			t.Fatalf("retry of stale claim = %v, want ErrJobLeaseLost", err)
+		}
+		if err := jobs.Bury(ctx, job.ID, 2, "failed"); err != nil {
+			t.Fatalf("bury: %v", err)
+		}
+		if err := jobs.Complete(ctx, job.ID, 2); !errors.Is(err, domain.ErrJobLeaseLost) {
+			t.Fatalf("complete of buried job = %v, want ErrJobLeaseLost", err)
+		}
+	})
+
+	t.Run("expired last attempt is buried", func(t *testing.T) {
+		job := enqueue(1)
+		if first := claim(); first == nil || first.ID != job.ID {
+			t.Fatalf("first claim = %+v, want job %d", first, job.ID)
+		}
+		expireLease(job.ID)
+
+		if again := claim(); again != nil && again.ID == job.ID {
+			t.Fatalf("job claimed again after its last attempt")
+		}
+		if s := status(job.ID); s != domain.JobDead {
+			t.Fatalf("status = %s, want %s", s, domain.JobDead)
+		}
+	})
+}
@@ -12,7 +12,48 @@ import (
 )
 
 type matchRepository struct {
-	db *sqlx.DB
+	db dbtx
+}
+
+// matchColumns lists match columns in scan order; icebreakers is a TEXT[]
+// and has to be scanned through pq.Array
+const matchColumns = `id, user1_id, user2_id, is_active, match_explanation, icebreakers, ai_fallback, created_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMatch(row rowScanner) (*domain.Match, error) {
+	var match domain.Match
+	err := row.Scan(
+		&match.ID, &match.User1ID, &match.User2ID, &match.IsActive,
+		&match.Explanation, pq.Array(&match.Icebreakers), &match.AIFallback, &match.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
+func (r *matchRepository) selectMatches(ctx context.Context, query string, args ...interface{}) ([]*domain.Match, error) {
+	ctx, span := startSpan(ctx, "MatchRepository.selectMatches")
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []*domain.Match
+	for rows.Next() {
+		match, err := scanMatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
 }
 
 func NewMatchRepository(db *sqlx.DB) repository.MatchRepository {
@@ -20,6 +61,9 @@ func NewMatchRepository(db *sqlx.DB) repository.MatchRepository {
 }
 
 func (r *matchRepository) Create(ctx context.Context, match *domain.Match) error {
+	ctx, span := startSpan(ctx, "MatchRepository.Create")
+	defer span.End()
+
 	// Ensure user1_id < user2_id for constraint
 	user1ID, user2ID := match.User1ID, match.User2ID
 	if user1ID > user2ID {
@@ -39,62 +83,115 @@ func (r *matchRepository) Create(ctx context.Context, match *domain.Match) error
 	return err
 }
 
+func (r *matchRepository) Upsert(ctx context.Context, match *domain.Match, swiperID int) error {
+	ctx, span := startSpan(ctx, "MatchRepository.Upsert")
+	defer span.End()
+
+	// Ensure user1_id < user2_id for constraint
+	user1ID, user2ID := match.User1ID, match.User2ID
+	if user1ID > user2ID {
+		user1ID, user2ID = user2ID, user1ID
+	}
+
+	// The update only applies to a match swiperID undid, otherwise the
+	// conflicting row is left alone and nothing is returned
+	query := `
+		INSERT INTO matches (user1_id, user2_id, is_active)
+		VALUES ($1, $2, true)
+		ON CONFLICT ON CONSTRAINT unique_match DO UPDATE SET is_active = true, undone_by = NULL
+		WHERE matches.undone_by = $3
+		RETURNING ` + matchColumns
+	upserted, err := scanMatch(r.db.QueryRowContext(ctx, query, user1ID, user2ID, swiperID))
+	if errors.Is(err, sql.ErrNoRows) {
+		upserted, err = r.GetByUsers(ctx, user1ID, user2ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	*match = *upserted
+	return nil
+}
+
 func (r *matchRepository) GetByID(ctx context.Context, id int) (*domain.Match, error) {
-	var match domain.Match
-	query := `SELECT * FROM matches WHERE id = $1`
-	err := r.db.GetContext(ctx, &match, query, id)
+	ctx, span := startSpan(ctx, "MatchRepository.GetByID")
+	defer span.End()
+
+	query := `SELECT ` + matchColumns + ` FROM matches WHERE id = $1`
+	match, err := scanMatch(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrMatchNotFound
 		}
 		return nil, err
 	}
-	return &match, nil
+	return match, nil
 }
 
 func (r *matchRepository) GetByUsers(ctx context.Context, user1ID, user2ID int) (*domain.Match, error) {
+	ctx, span := startSpan(ctx, "MatchRepository.GetByUsers")
+	defer span.End()
+
 	// Ensure user1_id < user2_id
 	if user1ID > user2ID {
 		user1ID, user2ID = user2ID, user1ID
 	}
 
-	var match domain.Match
-	query := `SELECT * FROM matches WHERE user1_id = $1 AND user2_id = $2`
-	err := r.db.GetContext(ctx, &match, query, user1ID, user2ID)
+	query := `SELECT ` + matchColumns + ` FROM matches WHERE user1_id = $1 AND user2_id = $2`
+	match, err := scanMatch(r.db.QueryRowContext(ctx, query, user1ID, user2ID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrMatchNotFound
 		}
 		return nil, err
 	}
-	return &match, nil
+	return match, nil
 }
 
 func (r *matchRepository) GetUserMatches(ctx context.Context, userID int, limit, offset int) ([]*domain.Match, error) {
-	var matches []*domain.Match
+	ctx, span := startSpan(ctx, "MatchRepository.GetUserMatches")
+	defer span.End()
+
 	query := `
-		SELECT * FROM matches
+		SELECT ` + matchColumns + ` FROM matches
 		WHERE (user1_id = $1 OR user2_id = $1)
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
-	err := r.db.SelectContext(ctx, &matches, query, userID, limit, offset)
-	return matches, err
+	return r.selectMatches(ctx, query, userID, limit, offset)
 }
 
-func (r *matchRepository) GetActiveMatches(ctx context.Context, userID int) ([]*domain.Match, error) {
-	var matches []*domain.Match
+func (r *matchRepository) GetActiveMatches(ctx context.Context, userID int, limit, offset int) ([]*domain.Match, error) {
+	ctx, span := startSpan(ctx, "MatchRepository.GetActiveMatches")
+	defer span.End()
+
 	query := `
-		SELECT * FROM matches
+		SELECT ` + matchColumns + ` FROM matches
+		WHERE (user1_id = $1 OR user2_id = $1) AND is_active = true
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+	return r.selectMatches(ctx, query, userID, limit, offset)
+}
+
+func (r *matchRepository) CountActiveMatches(ctx context.Context, userID int) (int, error) {
+	ctx, span := startSpan(ctx, "MatchRepository.CountActiveMatches")
+	defer span.End()
+
+	query := `
+		SELECT COUNT(*) FROM matches
 		WHERE (user1_id = $1 OR user2_id = $1) AND is_active = true
-		ORDER BY created_at DESC
 	`
-	err := r.db.SelectContext(ctx, &matches, query, userID)
-	return matches, err
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
 }
 
 func (r *matchRepository) UpdateStatus(ctx context.Context, id int, isActive bool) error {
-	query := `UPDATE matches SET is_active = $1 WHERE id = $2`
+	ctx, span := startSpan(ctx, "MatchRepository.UpdateStatus")
+	defer span.End()
+
+	query := `UPDATE matches SET is_active = $1, undone_by = NULL WHERE id = $2`
 	result, err := r.db.ExecContext(ctx, query, isActive, id)
 	if err != nil {
 		return err
@@ -109,7 +206,45 @@ func (r *matchRepository) UpdateStatus(ctx context.Context, id int, isActive boo
 	return nil
 }
 
+func (r *matchRepository) DeactivateByUndo(ctx context.Context, id, swiperID int) error {
+	ctx, span := startSpan(ctx, "MatchRepository.DeactivateByUndo")
+	defer span.End()
+
+	query := `UPDATE matches SET is_active = false, undone_by = $1 WHERE id = $2`
+	result, err := r.db.ExecContext(ctx, query, swiperID, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrMatchNotFound
+	}
+	return nil
+}
+
+func (r *matchRepository) DeactivateByUser(ctx context.Context, userID int) (int, error) {
+	ctx, span := startSpan(ctx, "MatchRepository.DeactivateByUser")
+	defer span.End()
+
+	query := `
+		UPDATE matches SET is_active = false, undone_by = NULL
+		WHERE (user1_id = $1 OR user2_id = $1) AND is_active = true
+	`
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
+
 func (r *matchRepository) Delete(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "MatchRepository.Delete")
+	defer span.End()
+
 	query := `DELETE FROM matches WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -125,13 +260,16 @@ func (r *matchRepository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
-func (r *matchRepository) UpdateAIFields(ctx context.Context, matchID int, explanation string, icebreakers []string) error {
+func (r *matchRepository) UpdateAIFields(ctx context.Context, matchID int, explanation string, icebreakers []string, fallback bool) error {
+	ctx, span := startSpan(ctx, "MatchRepository.UpdateAIFields")
+	defer span.End()
+
 	query := `
 		UPDATE matches 
-		SET match_explanation = $1, icebreakers = $2 
-		WHERE id = $3
+		SET match_explanation = $1, icebreakers = $2, ai_fallback = $3
+		WHERE id = $4
 	`
-	result, err := r.db.ExecContext(ctx, query, explanation, pq.Array(icebreakers), matchID)
+	result, err := r.db.ExecContext(ctx, query, explanation, pq.Array(icebreakers), fallback, matchID)
 	if err != nil {
 		return err
 	}
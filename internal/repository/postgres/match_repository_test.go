@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/testutil/pgtest"
+)
+
+func TestMatchUpsertKeepsEndedMatch(t *testing.T) {
+	db := pgtest.Open(t)
+	ctx := context.Background()
+	matches := NewMatchRepository(db)
+
+	upsert := func(swiperID, swipedID int) *domain.Match {
+		t.Helper()
+		match := &domain.Match{User1ID: swiperID, User2ID: swipedID, IsActive: true}
+		if err := matches.Upsert(ctx, match, swiperID); err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+		return match
+	}
+
+	user1 := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+	user2 := pgtest.SeedUser(t, db, string(domain.GenderFemale), "Тест")
+
+	created := upsert(user1, user2)
+	if !created.IsActive {
+		t.Fatal("new match is inactive")
+	}
+
+	// Undo by user1 and a new like of user1 bring the match back
+	if err := matches.DeactivateByUndo(ctx, created.ID, user1); err != nil {
+		t.Fatalf("deactivate by undo: %v", err)
+	}
+	if m := upsert(user2, user1); m.IsActive {
+		t.Fatal("like of the other user reactivated a match undone by user1")
+	}
+	if m := upsert(user1, user2); !m.IsActive || m.ID != created.ID {
+		t.Fatalf("like after undo: got active=%v id=%d, want active match %d", m.IsActive, m.ID, created.ID)
+	}
+
+	// A match ended with DELETE /matches/:id stays ended
+	if err := matches.UpdateStatus(ctx, created.ID, false); err != nil {
+		t.Fatalf("unmatch: %v", err)
+	}
+	if m := upsert(user1, user2); m.IsActive {
+		t.Fatal("upsert revived an ended match")
+	}
+}
+
+func TestMatchDeactivateByUser(t *testing.T) {
+	db := pgtest.Open(t)
+	ctx := context.Background()
+	matches := NewMatchRepository(db)
+
+	banned := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+	other1 := pgtest.SeedUser(t, db, string(domain.GenderFemale), "Тест")
+	other2 := pgtest.SeedUser(t, db, string(domain.GenderFemale), "Тест")
+
+	withBanned := &domain.Match{User1ID: other1, User2ID: banned, IsActive: true}
+	untouched := &domain.Match{User1ID: other1, User2ID: other2, IsActive: true}
+	for _, m := range []*domain.Match{withBanned, untouched} {
+		if err := matches.Upsert(ctx, m, m.User1ID); err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+	}
+
+	ended, err := matches.DeactivateByUser(ctx, banned)
+	if err != nil {
+		t.Fatalf("deactivate by user: %v", err)
+	}
+	if ended != 1 {
+		t.Fatalf("ended %d matches, want 1", ended)
+	}
+
+	if m, _ := matches.GetByID(ctx, withBanned.ID); m == nil || m.IsActive {
+		t.Fatal("match of the banned user is still active")
+	}
+	if m, _ := matches.GetByID(ctx, untouched.ID); m == nil || !m.IsActive {
+		t.Fatal("match of other users was ended")
+	}
+}
@@ -19,6 +19,9 @@ func NewMessageRepository(db *sqlx.DB) repository.MessageRepository {
 }
 
 func (r *messageRepository) Create(ctx context.Context, message *domain.Message) error {
+	ctx, span := startSpan(ctx, "MessageRepository.Create")
+	defer span.End()
+
 	query := `
 		INSERT INTO messages (match_id, sender_id, content, is_read)
 		VALUES ($1, $2, $3, $4)
@@ -31,6 +34,9 @@ func (r *messageRepository) Create(ctx context.Context, message *domain.Message)
 }
 
 func (r *messageRepository) GetByID(ctx context.Context, id int) (*domain.Message, error) {
+	ctx, span := startSpan(ctx, "MessageRepository.GetByID")
+	defer span.End()
+
 	var message domain.Message
 	query := `SELECT * FROM messages WHERE id = $1`
 	err := r.db.GetContext(ctx, &message, query, id)
@@ -43,19 +49,49 @@ func (r *messageRepository) GetByID(ctx context.Context, id int) (*domain.Messag
 	return &message, nil
 }
 
-func (r *messageRepository) GetMatchMessages(ctx context.Context, matchID int, limit, offset int) ([]*domain.Message, error) {
+// visibleToViewer hides messages of shadow-banned senders from everyone but
+// the sender, $2 is the viewer
+const visibleToViewer = `(sender_id = $2 OR NOT EXISTS (
+	SELECT 1 FROM users u WHERE u.id = sender_id AND u.is_shadow_banned
+))`
+
+// GetMatchMessagesBefore returns messages older than beforeID, newest first.
+// beforeID <= 0 starts from the latest message.
+func (r *messageRepository) GetMatchMessagesBefore(ctx context.Context, matchID, viewerID int, beforeID int, limit int) ([]*domain.Message, error) {
+	ctx, span := startSpan(ctx, "MessageRepository.GetMatchMessagesBefore")
+	defer span.End()
+
 	var messages []*domain.Message
 	query := `
 		SELECT * FROM messages
-		WHERE match_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		WHERE match_id = $1 AND ($3 <= 0 OR id < $3) AND ` + visibleToViewer + `
+		ORDER BY id DESC
+		LIMIT $4
 	`
-	err := r.db.SelectContext(ctx, &messages, query, matchID, limit, offset)
+	err := r.db.SelectContext(ctx, &messages, query, matchID, viewerID, beforeID, limit)
+	return messages, err
+}
+
+// GetMatchMessagesAfter returns messages newer than afterID, oldest first
+func (r *messageRepository) GetMatchMessagesAfter(ctx context.Context, matchID, viewerID int, afterID int, limit int) ([]*domain.Message, error) {
+	ctx, span := startSpan(ctx, "MessageRepository.GetMatchMessagesAfter")
+	defer span.End()
+
+	var messages []*domain.Message
+	query := `
+		SELECT * FROM messages
+		WHERE match_id = $1 AND id > $3 AND ` + visibleToViewer + `
+		ORDER BY id ASC
+		LIMIT $4
+	`
+	err := r.db.SelectContext(ctx, &messages, query, matchID, viewerID, afterID, limit)
 	return messages, err
 }
 
 func (r *messageRepository) MarkAsRead(ctx context.Context, messageID int) error {
+	ctx, span := startSpan(ctx, "MessageRepository.MarkAsRead")
+	defer span.End()
+
 	query := `UPDATE messages SET is_read = true WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, messageID)
 	if err != nil {
@@ -71,21 +107,49 @@ func (r *messageRepository) MarkAsRead(ctx context.Context, messageID int) error
 	return nil
 }
 
+// MarkMatchAsRead marks all messages sent to readerID in the match as read
+func (r *messageRepository) MarkMatchAsRead(ctx context.Context, matchID, readerID int) (int, error) {
+	ctx, span := startSpan(ctx, "MessageRepository.MarkMatchAsRead")
+	defer span.End()
+
+	query := `
+		UPDATE messages SET is_read = true
+		WHERE match_id = $1 AND sender_id != $2 AND is_read = false
+	`
+	result, err := r.db.ExecContext(ctx, query, matchID, readerID)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rows), nil
+}
+
 func (r *messageRepository) GetUnreadCount(ctx context.Context, userID int) (int, error) {
+	ctx, span := startSpan(ctx, "MessageRepository.GetUnreadCount")
+	defer span.End()
+
 	var count int
 	query := `
 		SELECT COUNT(*)
 		FROM messages m
 		JOIN matches ma ON m.match_id = ma.id
+		JOIN users u ON u.id = m.sender_id
 		WHERE (ma.user1_id = $1 OR ma.user2_id = $1)
 		AND m.sender_id != $1
 		AND m.is_read = false
+		AND NOT u.is_shadow_banned
 	`
 	err := r.db.GetContext(ctx, &count, query, userID)
 	return count, err
 }
 
 func (r *messageRepository) Delete(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "MessageRepository.Delete")
+	defer span.End()
+
 	query := `DELETE FROM messages WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/testutil/pgtest"
+)
+
+func TestMessagesOfShadowBannedSender(t *testing.T) {
+	db := pgtest.Open(t)
+	ctx := context.Background()
+	messages := NewMessageRepository(db)
+
+	sender := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+	recipient := pgtest.SeedUser(t, db, string(domain.GenderFemale), "Тест")
+
+	match := &domain.Match{User1ID: sender, User2ID: recipient, IsActive: true}
+	if err := NewMatchRepository(db).Upsert(ctx, match, sender); err != nil {
+		t.Fatalf("create match: %v", err)
+	}
+
+	send := func(senderID int) {
+		t.Helper()
+		if err := messages.Create(ctx, &domain.Message{MatchID: match.ID, SenderID: senderID, Content: "x"}); err != nil {
+			t.Fatalf("create message: %v", err)
+		}
+	}
+	send(recipient)
+	send(sender)
+
+	if _, err := db.ExecContext(ctx, `UPDATE users SET is_shadow_banned = true WHERE id = $1`, sender); err != nil {
+		t.Fatalf("shadow ban: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		viewer    int
+		wantCount int
+	}{
+		{name: "sender sees own messages", viewer: sender, wantCount: 2},
+		{name: "recipient doesn't", viewer: recipient, wantCount: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := messages.GetMatchMessagesBefore(ctx, match.ID, tt.viewer, 0, 10)
+			if err != nil {
+				t.Fatalf("before: %v", err)
+			}
+			after, err := messages.GetMatchMessagesAfter(ctx, match.ID, tt.viewer, 0, 10)
+			if err != nil {
+				t.Fatalf("after: %v", err)
+			}
+			if len(before) != tt.wantCount || len(after) != tt.wantCount {
+				t.Fatalf("got %d messages before and %d after, want %d", len(before), len(after), tt.wantCount)
+			}
+			for _, msg := range before {
+				if msg.SenderID == sender && tt.viewer != sender {
+					t.Fatal("message of shadow-banned sender returned to recipient")
+				}
+			}
+		})
+	}
+
+	unread, err := messages.GetUnreadCount(ctx, recipient)
+	if err != nil {
+		t.Fatalf("unread count: %v", err)
+	}
+	if unread != 0 {
+		t.Fatalf("unread count = %d, want 0", unread)
+	}
+}
@@ -19,24 +19,34 @@ func NewNotificationRepository(db *sqlx.DB) repository.NotificationRepository {
 }
 
 func (r *notificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	ctx, span := startSpan(ctx, "NotificationRepository.Create")
+	defer span.End()
+
 	query := `
-		INSERT INTO notifications (user_id, content, is_read)
-		VALUES ($1, $2, $3)
+		INSERT INTO notifications (user_id, type, payload, is_read)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id, created_at
 	`
+	payload := notification.Payload
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
 	return r.db.QueryRowContext(
 		ctx, query,
-		notification.UserID, notification.Content, notification.IsRead,
+		notification.UserID, notification.Type, []byte(payload), notification.IsRead,
 	).Scan(&notification.ID, &notification.CreatedAt)
 }
 
 func (r *notificationRepository) GetByID(ctx context.Context, id int) (*domain.Notification, error) {
+	ctx, span := startSpan(ctx, "NotificationRepository.GetByID")
+	defer span.End()
+
 	var notification domain.Notification
 	query := `SELECT * FROM notifications WHERE id = $1`
 	err := r.db.GetContext(ctx, &notification, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("notification not found")
+			return nil, domain.ErrNotificationNotFound
 		}
 		return nil, err
 	}
@@ -44,6 +54,9 @@ func (r *notificationRepository) GetByID(ctx context.Context, id int) (*domain.N
 }
 
 func (r *notificationRepository) GetUserNotifications(ctx context.Context, userID int, limit, offset int) ([]*domain.Notification, error) {
+	ctx, span := startSpan(ctx, "NotificationRepository.GetUserNotifications")
+	defer span.End()
+
 	var notifications []*domain.Notification
 	query := `
 		SELECT * FROM notifications
@@ -56,6 +69,9 @@ func (r *notificationRepository) GetUserNotifications(ctx context.Context, userI
 }
 
 func (r *notificationRepository) MarkAsRead(ctx context.Context, notificationID int) error {
+	ctx, span := startSpan(ctx, "NotificationRepository.MarkAsRead")
+	defer span.End()
+
 	query := `UPDATE notifications SET is_read = true WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, notificationID)
 	if err != nil {
@@ -66,18 +82,24 @@ func (r *notificationRepository) MarkAsRead(ctx context.Context, notificationID
 		return err
 	}
 	if rows == 0 {
-		return errors.New("notification not found")
+		return domain.ErrNotificationNotFound
 	}
 	return nil
 }
 
 func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID int) error {
+	ctx, span := startSpan(ctx, "NotificationRepository.MarkAllAsRead")
+	defer span.End()
+
 	query := `UPDATE notifications SET is_read = true WHERE user_id = $1 AND is_read = false`
 	_, err := r.db.ExecContext(ctx, query, userID)
 	return err
 }
 
 func (r *notificationRepository) GetUnreadCount(ctx context.Context, userID int) (int, error) {
+	ctx, span := startSpan(ctx, "NotificationRepository.GetUnreadCount")
+	defer span.End()
+
 	var count int
 	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND is_read = false`
 	err := r.db.GetContext(ctx, &count, query, userID)
@@ -85,6 +107,9 @@ func (r *notificationRepository) GetUnreadCount(ctx context.Context, userID int)
 }
 
 func (r *notificationRepository) Delete(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "NotificationRepository.Delete")
+	defer span.End()
+
 	query := `DELETE FROM notifications WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -95,7 +120,7 @@ func (r *notificationRepository) Delete(ctx context.Context, id int) error {
 		return err
 	}
 	if rows == 0 {
-		return errors.New("notification not found")
+		return domain.ErrNotificationNotFound
 	}
 	return nil
 }
@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type photoRepository struct {
+	db *sqlx.DB
+}
+
+func NewPhotoRepository(db *sqlx.DB) repository.PhotoRepository {
+	return &photoRepository{db: db}
+}
+
+func (r *photoRepository) Create(ctx context.Context, photo *domain.Photo) error {
+	ctx, span := startSpan(ctx, "PhotoRepository.Create")
+	defer span.End()
+
+	return r.withUserLock(ctx, photo.UserID, func(tx *sqlx.Tx) error {
+		var count int
+		if err := tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM photos WHERE user_id = $1`, photo.UserID); err != nil {
+			return err
+		}
+		if count >= domain.MaxPhotosPerUser {
+			return domain.ErrPhotoLimitReached
+		}
+
+		query := `
+			INSERT INTO photos (user_id, storage_key, thumbnail_key, width, height, size_bytes, position, is_primary, source_url)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (user_id, source_url) WHERE source_url IS NOT NULL DO NOTHING
+			RETURNING id, created_at
+		`
+		photo.Position = count
+		photo.IsPrimary = count == 0
+		err := tx.QueryRowContext(
+			ctx, query,
+			photo.UserID, photo.StorageKey, photo.ThumbnailKey,
+			photo.Width, photo.Height, photo.SizeBytes,
+			photo.Position, photo.IsPrimary, photo.SourceURL,
+		).Scan(&photo.ID, &photo.CreatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrPhotoAlreadyImported
+		}
+		return err
+	})
+}
+
+func (r *photoRepository) GetByID(ctx context.Context, id int) (*domain.Photo, error) {
+	ctx, span := startSpan(ctx, "PhotoRepository.GetByID")
+	defer span.End()
+
+	var photo domain.Photo
+	query := `SELECT * FROM photos WHERE id = $1`
+	err := r.db.GetContext(ctx, &photo, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrPhotoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+func (r *photoRepository) GetBySourceURL(ctx context.Context, userID int, sourceURL string) (*domain.Photo, error) {
+	ctx, span := startSpan(ctx, "PhotoRepository.GetBySourceURL")
+	defer span.End()
+
+	var photo domain.Photo
+	query := `SELECT * FROM photos WHERE user_id = $1 AND source_url = $2`
+	err := r.db.GetContext(ctx, &photo, query, userID, sourceURL)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrPhotoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+func (r *photoRepository) GetByUserID(ctx context.Context, userID int) ([]*domain.Photo, error) {
+	ctx, span := startSpan(ctx, "PhotoRepository.GetByUserID")
+	defer span.End()
+
+	var photos []*domain.Photo
+	query := `SELECT * FROM photos WHERE user_id = $1 ORDER BY position`
+	err := r.db.SelectContext(ctx, &photos, query, userID)
+	return photos, err
+}
+
+func (r *photoRepository) GetByUserIDs(ctx context.Context, userIDs []int) ([]*domain.Photo, error) {
+	ctx, span := startSpan(ctx, "PhotoRepository.GetByUserIDs")
+	defer span.End()
+
+	var photos []*domain.Photo
+	if len(userIDs) == 0 {
+		return photos, nil
+	}
+	query := `SELECT * FROM photos WHERE user_id = ANY($1) ORDER BY user_id, position`
+	err := r.db.SelectContext(ctx, &photos, query, pq.Array(userIDs))
+	return photos, err
+}
+
+func (r *photoRepository) Delete(ctx context.Context, userID, id int) error {
+	ctx, span := startSpan(ctx, "PhotoRepository.Delete")
+	defer span.End()
+
+	return r.withUserLock(ctx, userID, func(tx *sqlx.Tx) error {
+		var deleted domain.Photo
+		query := `DELETE FROM photos WHERE id = $1 AND user_id = $2 RETURNING position, is_primary`
+		err := tx.QueryRowContext(ctx, query, id, userID).Scan(&deleted.Position, &deleted.IsPrimary)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrPhotoNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		query = `UPDATE photos SET position = position - 1 WHERE user_id = $1 AND position > $2`
+		if _, err := tx.ExecContext(ctx, query, userID, deleted.Position); err != nil {
+			return err
+		}
+
+		if deleted.IsPrimary {
+			query = `
+				UPDATE photos SET is_primary = true
+				WHERE id = (SELECT id FROM photos WHERE user_id = $1 ORDER BY position LIMIT 1)
+			`
+			if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *photoRepository) SetPrimary(ctx context.Context, userID, id int) error {
+	ctx, span := startSpan(ctx, "PhotoRepository.SetPrimary")
+	defer span.End()
+
+	return r.withUserLock(ctx, userID, func(tx *sqlx.Tx) error {
+		// Unset first, the partial unique index allows one primary at a time
+		query := `UPDATE photos SET is_primary = false WHERE user_id = $1 AND is_primary AND id <> $2`
+		if _, err := tx.ExecContext(ctx, query, userID, id); err != nil {
+			return err
+		}
+
+		query = `UPDATE photos SET is_primary = true WHERE id = $1 AND user_id = $2`
+		result, err := tx.ExecContext(ctx, query, id, userID)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return domain.ErrPhotoNotFound
+		}
+		return nil
+	})
+}
+
+func (r *photoRepository) Move(ctx context.Context, userID, id, position int) error {
+	ctx, span := startSpan(ctx, "PhotoRepository.Move")
+	defer span.End()
+
+	return r.withUserLock(ctx, userID, func(tx *sqlx.Tx) error {
+		var current, count int
+		query := `SELECT position FROM photos WHERE id = $1 AND user_id = $2`
+		err := tx.GetContext(ctx, &current, query, id, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrPhotoNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM photos WHERE user_id = $1`, userID); err != nil {
+			return err
+		}
+
+		position = max(0, min(position, count-1))
+		if position == current {
+			return nil
+		}
+
+		if position < current {
+			query = `UPDATE photos SET position = position + 1 WHERE user_id = $1 AND position >= $2 AND position < $3`
+			_, err = tx.ExecContext(ctx, query, userID, position, current)
+		} else {
+			query = `UPDATE photos SET position = position - 1 WHERE user_id = $1 AND position > $2 AND position <= $3`
+			_, err = tx.ExecContext(ctx, query, userID, current, position)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE photos SET position = $1 WHERE id = $2`, position, id)
+		return err
+	})
+}
+
+// withUserLock runs fn in a transaction holding the user's row lock, so
+// concurrent uploads and reorders of one user's photos are serialized
+func (r *photoRepository) withUserLock(ctx context.Context, userID int, fn func(tx *sqlx.Tx) error) error {
+	ctx, span := startSpan(ctx, "PhotoRepository.withUserLock")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var locked int
+	err = tx.GetContext(ctx, &locked, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -13,7 +13,7 @@ import (
 )
 
 type profileRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func NewProfileRepository(db *sqlx.DB) repository.ProfileRepository {
@@ -21,15 +21,20 @@ func NewProfileRepository(db *sqlx.DB) repository.ProfileRepository {
 }
 
 func (r *profileRepository) Create(ctx context.Context, profile *domain.Profile) error {
+	ctx, span := startSpan(ctx, "ProfileRepository.Create")
+	defer span.End()
+
 	query := `
 		INSERT INTO profiles (
 			user_id, display_name, bio, city, interests,
 			location_lat, location_lon, location_updated_at,
 			pref_min_age, pref_max_age, pref_max_distance_km, is_onboarding_complete,
-			pref_openness, pref_conscientiousness, pref_extraversion, pref_agreeableness, pref_neuroticism
+			pref_openness, pref_conscientiousness, pref_extraversion, pref_agreeableness, pref_neuroticism,
+			interested_in
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
-		RETURNING id, created_at, updated_at
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17,
+			COALESCE(NULLIF($18, ''), 'everyone'))
+		RETURNING id, interested_in, created_at, updated_at
 	`
 	return r.db.QueryRowContext(
 		ctx, query,
@@ -39,10 +44,14 @@ func (r *profileRepository) Create(ctx context.Context, profile *domain.Profile)
 		profile.PrefMaxDistanceKm, profile.IsOnboardingComplete,
 		profile.PrefOpenness, profile.PrefConscientiousness, profile.PrefExtraversion,
 		profile.PrefAgreeableness, profile.PrefNeuroticism,
-	).Scan(&profile.ID, &profile.CreatedAt, &profile.UpdatedAt)
+		profile.InterestedIn,
+	).Scan(&profile.ID, &profile.InterestedIn, &profile.CreatedAt, &profile.UpdatedAt)
 }
 
 func (r *profileRepository) GetByID(ctx context.Context, id int) (*domain.Profile, error) {
+	ctx, span := startSpan(ctx, "ProfileRepository.GetByID")
+	defer span.End()
+
 	var profile domain.Profile
 	query := `SELECT * FROM profiles WHERE id = $1`
 	err := r.db.GetContext(ctx, &profile, query, id)
@@ -56,21 +65,36 @@ func (r *profileRepository) GetByID(ctx context.Context, id int) (*domain.Profil
 }
 
 func (r *profileRepository) GetByUserID(ctx context.Context, userID int) (*domain.Profile, error) {
+	ctx, span := startSpan(ctx, "ProfileRepository.GetByUserID")
+	defer span.End()
+
+	return r.getByUserID(ctx, userID, "")
+}
+
+// GetByUserIDForUpdate locks the profile row until the transaction ends
+func (r *profileRepository) GetByUserIDForUpdate(ctx context.Context, userID int) (*domain.Profile, error) {
+	ctx, span := startSpan(ctx, "ProfileRepository.GetByUserIDForUpdate")
+	defer span.End()
+
+	return r.getByUserID(ctx, userID, "FOR UPDATE")
+}
+
+func (r *profileRepository) getByUserID(ctx context.Context, userID int, lock string) (*domain.Profile, error) {
 	var profile domain.Profile
 	query := `
 		SELECT id, user_id, display_name, bio, city, interests,
 		       location_lat, location_lon, location_updated_at,
-		       pref_min_age, pref_max_age, pref_max_distance_km,
+		       pref_min_age, pref_max_age, pref_max_distance_km, interested_in,
 		       is_onboarding_complete,
 		       pref_openness, pref_conscientiousness, pref_extraversion,
 		       pref_agreeableness, pref_neuroticism,
 		       created_at, updated_at
 		FROM profiles WHERE user_id = $1
-	`
+	` + lock
 	err := r.db.QueryRowContext(ctx, query, userID).Scan(
 		&profile.ID, &profile.UserID, &profile.DisplayName, &profile.Bio, &profile.City, pq.Array(&profile.Interests),
 		&profile.LocationLat, &profile.LocationLon, &profile.LocationUpdatedAt,
-		&profile.PrefMinAge, &profile.PrefMaxAge, &profile.PrefMaxDistanceKm,
+		&profile.PrefMinAge, &profile.PrefMaxAge, &profile.PrefMaxDistanceKm, &profile.InterestedIn,
 		&profile.IsOnboardingComplete,
 		&profile.PrefOpenness, &profile.PrefConscientiousness, &profile.PrefExtraversion,
 		&profile.PrefAgreeableness, &profile.PrefNeuroticism,
@@ -86,6 +110,9 @@ func (r *profileRepository) GetByUserID(ctx context.Context, userID int) (*domai
 }
 
 func (r *profileRepository) Update(ctx context.Context, profile *domain.Profile) error {
+	ctx, span := startSpan(ctx, "ProfileRepository.Update")
+	defer span.End()
+
 	query := `
 		UPDATE profiles
 		SET display_name = $1, bio = $2, city = $3, interests = $4,
@@ -94,8 +121,9 @@ func (r *profileRepository) Update(ctx context.Context, profile *domain.Profile)
 		    is_onboarding_complete = $11,
 			pref_openness = $12, pref_conscientiousness = $13, pref_extraversion = $14,
 			pref_agreeableness = $15, pref_neuroticism = $16,
+			interested_in = COALESCE(NULLIF($17, ''), interested_in),
 			updated_at = CURRENT_TIMESTAMP
-		WHERE id = $17
+		WHERE id = $18
 		RETURNING updated_at
 	`
 	return r.db.QueryRowContext(
@@ -106,11 +134,15 @@ func (r *profileRepository) Update(ctx context.Context, profile *domain.Profile)
 		profile.IsOnboardingComplete,
 		profile.PrefOpenness, profile.PrefConscientiousness, profile.PrefExtraversion,
 		profile.PrefAgreeableness, profile.PrefNeuroticism,
+		profile.InterestedIn,
 		profile.ID,
 	).Scan(&profile.UpdatedAt)
 }
 
 func (r *profileRepository) Delete(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "ProfileRepository.Delete")
+	defer span.End()
+
 	query := `DELETE FROM profiles WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -127,6 +159,9 @@ func (r *profileRepository) Delete(ctx context.Context, id int) error {
 }
 
 func (r *profileRepository) UpdateOnboardingStatus(ctx context.Context, userID int, isComplete bool) error {
+	ctx, span := startSpan(ctx, "ProfileRepository.UpdateOnboardingStatus")
+	defer span.End()
+
 	query := `
 		UPDATE profiles
 		SET is_onboarding_complete = $1, updated_at = CURRENT_TIMESTAMP
@@ -147,6 +182,9 @@ func (r *profileRepository) UpdateOnboardingStatus(ctx context.Context, userID i
 }
 
 func (r *profileRepository) SearchProfiles(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*domain.Profile, error) {
+	ctx, span := startSpan(ctx, "ProfileRepository.SearchProfiles")
+	defer span.End()
+
 	var profiles []*domain.Profile
 
 	query := `SELECT * FROM profiles WHERE 1=1`
@@ -177,3 +215,112 @@ func (r *profileRepository) SearchProfiles(ctx context.Context, filters map[stri
 	err := r.db.SelectContext(ctx, &profiles, query, args...)
 	return profiles, err
 }
+
+// GetFeedCandidates returns candidate profiles joined with their users in a single query.
+// Already swiped users are excluded with an anti-join on swipes.
+func (r *profileRepository) GetFeedCandidates(ctx context.Context, filter repository.CandidateFilter) ([]*domain.Candidate, error) {
+	ctx, span := startSpan(ctx, "ProfileRepository.GetFeedCandidates")
+	defer span.End()
+
+	query := `
+		SELECT p.id, p.user_id, p.display_name, p.bio, p.city, p.interests,
+		       p.location_lat, p.location_lon, p.location_updated_at,
+		       p.pref_min_age, p.pref_max_age, p.pref_max_distance_km, p.interested_in,
+		       p.is_onboarding_complete,
+		       p.pref_openness, p.pref_conscientiousness, p.pref_extraversion,
+		       p.pref_agreeableness, p.pref_neuroticism,
+		       p.created_at, p.updated_at,
+		       u.id, u.vk_id, u.gender, u.birth_date, u.is_verified, u.is_online,
+		       u.last_online_at, u.is_synthetic, u.created_at, u.updated_at,
+		       EXISTS (
+		           SELECT 1 FROM swipes sl
+		           WHERE sl.swiper_id = p.user_id AND sl.swiped_id = $1 AND sl.direction = 'super'
+		       ) AS super_liked
+		FROM profiles p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.user_id <> $1
+		  AND u.is_banned = false AND u.is_shadow_banned = false
+		  AND NOT EXISTS (
+		      SELECT 1 FROM swipes s
+		      WHERE s.swiper_id = $1 AND s.swiped_id = p.user_id
+		  )
+		  AND NOT EXISTS (
+		      SELECT 1 FROM blocks b
+		      WHERE (b.blocker_id = $1 AND b.blocked_id = p.user_id)
+		         OR (b.blocker_id = p.user_id AND b.blocked_id = $1)
+		  )`
+	args := []interface{}{filter.ViewerID}
+	argCount := 2
+
+	// addFilter appends a condition, numbering its $%d placeholders
+	addFilter := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			placeholders[i] = argCount
+			args = append(args, value)
+			argCount++
+		}
+		query += " AND " + fmt.Sprintf(condition, placeholders...)
+	}
+
+	if len(filter.Genders) > 0 {
+		addFilter("u.gender = ANY($%d)", pq.Array(filter.Genders))
+	}
+	if len(filter.InterestedIn) > 0 {
+		addFilter("p.interested_in = ANY($%d)", pq.Array(filter.InterestedIn))
+	}
+	if filter.City != nil {
+		addFilter("p.city = $%d", *filter.City)
+	}
+	if filter.BornAfter != nil {
+		addFilter("u.birth_date > $%d", *filter.BornAfter)
+	}
+	if filter.BornBefore != nil {
+		addFilter("u.birth_date <= $%d", *filter.BornBefore)
+	}
+	if filter.MinLat != nil && filter.MaxLat != nil && filter.MinLon != nil && filter.MaxLon != nil {
+		// Candidates without location are kept
+		addFilter(
+			"(p.location_lat IS NULL OR p.location_lon IS NULL OR (p.location_lat BETWEEN $%d AND $%d AND p.location_lon BETWEEN $%d AND $%d))",
+			*filter.MinLat, *filter.MaxLat, *filter.MinLon, *filter.MaxLon,
+		)
+	}
+	if filter.ExcludeSynthetic {
+		query += " AND u.is_synthetic = false"
+	}
+
+	// Super-likers always make it into the candidate pool
+	query += fmt.Sprintf(" ORDER BY super_liked DESC, p.created_at DESC LIMIT $%d", argCount)
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []*domain.Candidate
+	for rows.Next() {
+		var profile domain.Profile
+		var user domain.User
+		var superLiked bool
+		err := rows.Scan(
+			&profile.ID, &profile.UserID, &profile.DisplayName, &profile.Bio, &profile.City, pq.Array(&profile.Interests),
+			&profile.LocationLat, &profile.LocationLon, &profile.LocationUpdatedAt,
+			&profile.PrefMinAge, &profile.PrefMaxAge, &profile.PrefMaxDistanceKm, &profile.InterestedIn,
+			&profile.IsOnboardingComplete,
+			&profile.PrefOpenness, &profile.PrefConscientiousness, &profile.PrefExtraversion,
+			&profile.PrefAgreeableness, &profile.PrefNeuroticism,
+			&profile.CreatedAt, &profile.UpdatedAt,
+			&user.ID, &user.VKID, &user.Gender, &user.BirthDate, &user.IsVerified, &user.IsOnline,
+			&user.LastOnlineAt, &user.IsSynthetic, &user.CreatedAt, &user.UpdatedAt,
+			&superLiked,
+		)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &domain.Candidate{Profile: &profile, User: &user, SuperLiked: superLiked})
+	}
+
+	return candidates, rows.Err()
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/testutil/pgtest"
+)
+
+// BenchmarkGetFeedCandidates compares the feed query with the per-candidate
+// lookups the feed used to make. Run against a migrated database:
+//
+//	TEST_DATABASE_URL=postgres://... go test -run '^$' -bench GetFeedCandidates ./internal/repository/postgres
+func BenchmarkGetFeedCandidates(b *testing.B) {
+	const (
+		city       = "Бенчмарк"
+		candidates = 100
+	)
+
+	db := pgtest.Open(b)
+	ctx := context.Background()
+
+	profiles := NewProfileRepository(db)
+	users := NewUserRepository(db)
+	swipes := NewSwipeRepository(db)
+
+	viewerID := pgtest.SeedUser(b, db, string(domain.GenderMale), city)
+	for i := 0; i < candidates; i++ {
+		candidateID := pgtest.SeedUser(b, db, string(domain.GenderFemale), city)
+		// A third of the pool is already swiped and must be skipped
+		if i%3 == 0 {
+			swipe := &domain.Swipe{SwiperID: viewerID, SwipedID: candidateID, Direction: domain.SwipeLeft}
+			if err := swipes.Create(ctx, swipe); err != nil {
+				b.Fatalf("seed swipe: %v", err)
+			}
+		}
+	}
+
+	b.Run("N+1", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			found, err := profiles.SearchProfiles(ctx, map[string]interface{}{"city": city}, candidates, 0)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var result []*domain.Candidate
+			for _, profile := range found {
+				if profile.UserID == viewerID {
+					continue
+				}
+				swipe, err := swipes.GetByUsers(ctx, viewerID, profile.UserID)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if swipe != nil {
+					continue
+				}
+				user, err := users.GetByID(ctx, profile.UserID)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if user.Gender != domain.GenderFemale {
+					continue
+				}
+				result = append(result, &domain.Candidate{Profile: profile, User: user})
+			}
+			if len(result) == 0 {
+				b.Fatal("no candidates found")
+			}
+		}
+	})
+
+	b.Run("JOIN", func(b *testing.B) {
+		cityFilter := city
+		filter := repository.CandidateFilter{
+			ViewerID: viewerID,
+			Genders:  []domain.Gender{domain.GenderFemale},
+			City:     &cityFilter,
+			Limit:    candidates,
+		}
+		for i := 0; i < b.N; i++ {
+			result, err := profiles.GetFeedCandidates(ctx, filter)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(result) == 0 {
+				b.Fatal("no candidates found")
+			}
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+type quotaRepository struct {
+	db dbtx
+}
+
+func NewQuotaRepository(db *sqlx.DB) repository.QuotaRepository {
+	return &quotaRepository{db: db}
+}
+
+func (r *quotaRepository) Get(ctx context.Context, userID int, direction domain.SwipeDirection, now time.Time) (*domain.SwipeQuota, error) {
+	ctx, span := startSpan(ctx, "QuotaRepository.Get")
+	defer span.End()
+
+	var quota domain.SwipeQuota
+	query := `
+		SELECT used, resets_at FROM swipe_quotas
+		WHERE user_id = $1 AND direction = $2 AND resets_at > $3
+	`
+	err := r.db.GetContext(ctx, &quota, query, userID, direction, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+func (r *quotaRepository) Consume(ctx context.Context, userID int, direction domain.SwipeDirection, limit int, now, nextReset time.Time) (*domain.SwipeQuota, error) {
+	ctx, span := startSpan(ctx, "QuotaRepository.Consume")
+	defer span.End()
+
+	// The conflicting row stays locked until the transaction ends, so
+	// concurrent swipes of the user are counted one after another
+	var quota domain.SwipeQuota
+	query := `
+		INSERT INTO swipe_quotas (user_id, direction, used, resets_at)
+		VALUES ($1, $2, 1, $5)
+		ON CONFLICT (user_id, direction) DO UPDATE SET
+			used = CASE WHEN swipe_quotas.resets_at <= $4 THEN 1 ELSE swipe_quotas.used + 1 END,
+			resets_at = CASE WHEN swipe_quotas.resets_at <= $4 THEN EXCLUDED.resets_at ELSE swipe_quotas.resets_at END
+		WHERE swipe_quotas.resets_at <= $4 OR $3 <= 0 OR swipe_quotas.used < $3
+		RETURNING used, resets_at
+	`
+	err := r.db.GetContext(ctx, &quota, query, userID, direction, limit, now, nextReset)
+	if errors.Is(err, sql.ErrNoRows) {
+		current, err := r.Get(ctx, userID, direction, now)
+		if err != nil {
+			return nil, err
+		}
+		return current, domain.ErrQuotaExceeded
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/testutil/pgtest"
+)
+
+func TestQuotaConsume(t *testing.T) {
+	db := pgtest.Open(t)
+	ctx := context.Background()
+	quotas := NewQuotaRepository(db)
+
+	userID := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+	now := time.Now()
+	midnight := now.Add(time.Hour)
+
+	for i := 1; i <= 2; i++ {
+		quota, err := quotas.Consume(ctx, userID, domain.SwipeRight, 2, now, midnight)
+		if err != nil {
+			t.Fatalf("consume %d: %v", i, err)
+		}
+		if quota.Used != i {
+			t.Fatalf("consume %d: used = %d", i, quota.Used)
+		}
+	}
+
+	quota, err := quotas.Consume(ctx, userID, domain.SwipeRight, 2, now, midnight)
+	if !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("consume over the limit: got %v, want ErrQuotaExceeded", err)
+	}
+	if quota == nil || quota.Used != 2 || !quota.ResetsAt.Equal(midnight.Truncate(time.Microsecond)) {
+		t.Fatalf("usage over the limit = %+v", quota)
+	}
+
+	// A later midnight, as after a timezone change, doesn't open a new window
+	if _, err := quotas.Consume(ctx, userID, domain.SwipeRight, 2, now, midnight.Add(3*time.Hour)); !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("consume with another midnight: got %v, want ErrQuotaExceeded", err)
+	}
+
+	// Once the window is over counting starts again
+	quota, err = quotas.Consume(ctx, userID, domain.SwipeRight, 2, midnight, midnight.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("consume after reset: %v", err)
+	}
+	if quota.Used != 1 {
+		t.Fatalf("used after reset = %d, want 1", quota.Used)
+	}
+
+	// Super-likes are counted apart from likes
+	if got, err := quotas.Get(ctx, userID, domain.SwipeSuper, now); err != nil || got != nil {
+		t.Fatalf("super-like usage = %+v, %v, want none", got, err)
+	}
+}
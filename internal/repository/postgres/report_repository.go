@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+type reportRepository struct {
+	db dbtx
+}
+
+func NewReportRepository(db *sqlx.DB) repository.ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) Create(ctx context.Context, report *domain.Report) error {
+	ctx, span := startSpan(ctx, "ReportRepository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO reports (reporter_id, reported_id, reason, details, message_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, created_at
+	`
+	return r.db.QueryRowContext(
+		ctx, query,
+		report.ReporterID, report.ReportedID, report.Reason, report.Details, report.MessageID,
+	).Scan(&report.ID, &report.Status, &report.CreatedAt)
+}
+
+func (r *reportRepository) GetByID(ctx context.Context, id int) (*domain.Report, error) {
+	ctx, span := startSpan(ctx, "ReportRepository.GetByID")
+	defer span.End()
+
+	var report domain.Report
+	query := `SELECT * FROM reports WHERE id = $1`
+	err := r.db.GetContext(ctx, &report, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrReportNotFound
+		}
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *reportRepository) List(ctx context.Context, status *domain.ReportStatus, limit, offset int) ([]*domain.Report, error) {
+	ctx, span := startSpan(ctx, "ReportRepository.List")
+	defer span.End()
+
+	var reports []*domain.Report
+	query := `
+		SELECT * FROM reports
+		WHERE ($1::varchar IS NULL OR status = $1)
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+	err := r.db.SelectContext(ctx, &reports, query, status, limit, offset)
+	return reports, err
+}
+
+func (r *reportRepository) Resolve(ctx context.Context, report *domain.Report) error {
+	ctx, span := startSpan(ctx, "ReportRepository.Resolve")
+	defer span.End()
+
+	query := `
+		UPDATE reports
+		SET status = $1, resolved_by = $2, resolution = $3, resolved_at = CURRENT_TIMESTAMP
+		WHERE id = $4 AND status = 'pending'
+		RETURNING resolved_at
+	`
+	err := r.db.QueryRowContext(
+		ctx, query,
+		report.Status, report.ResolvedBy, report.Resolution, report.ID,
+	).Scan(&report.ResolvedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := r.GetByID(ctx, report.ID); err != nil {
+			return err
+		}
+		return domain.ErrReportResolved
+	}
+	return err
+}
@@ -11,7 +11,7 @@ import (
 )
 
 type sessionRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func NewSessionRepository(db *sqlx.DB) repository.SessionRepository {
@@ -19,19 +19,41 @@ func NewSessionRepository(db *sqlx.DB) repository.SessionRepository {
 }
 
 func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	ctx, span := startSpan(ctx, "SessionRepository.Create")
+	defer span.End()
+
 	query := `
-		INSERT INTO sessions (user_id, token, device_info, ip_address, expires_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at
+		INSERT INTO sessions (user_id, family_id, token, device_info, ip_address, expires_at)
+		VALUES ($1, COALESCE(NULLIF($2, '')::uuid, gen_random_uuid()), $3, $4, $5, $6)
+		RETURNING id, family_id, created_at
 	`
 	return r.db.QueryRowContext(
 		ctx, query,
-		session.UserID, session.Token, session.DeviceInfo,
+		session.UserID, session.FamilyID, session.Token, session.DeviceInfo,
 		session.IPAddress, session.ExpiresAt,
-	).Scan(&session.ID, &session.CreatedAt)
+	).Scan(&session.ID, &session.FamilyID, &session.CreatedAt)
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id int) (*domain.Session, error) {
+	ctx, span := startSpan(ctx, "SessionRepository.GetByID")
+	defer span.End()
+
+	var session domain.Session
+	query := `SELECT * FROM sessions WHERE id = $1`
+	err := r.db.GetContext(ctx, &session, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
 }
 
 func (r *sessionRepository) GetByToken(ctx context.Context, token string) (*domain.Session, error) {
+	ctx, span := startSpan(ctx, "SessionRepository.GetByToken")
+	defer span.End()
+
 	var session domain.Session
 	query := `SELECT * FROM sessions WHERE token = $1`
 	err := r.db.GetContext(ctx, &session, query, token)
@@ -48,10 +70,14 @@ func (r *sessionRepository) GetByToken(ctx context.Context, token string) (*doma
 }
 
 func (r *sessionRepository) GetByUserID(ctx context.Context, userID int) ([]*domain.Session, error) {
+	ctx, span := startSpan(ctx, "SessionRepository.GetByUserID")
+	defer span.End()
+
 	var sessions []*domain.Session
 	query := `
 		SELECT * FROM sessions
 		WHERE user_id = $1 AND expires_at > CURRENT_TIMESTAMP
+			AND rotated_at IS NULL AND revoked_at IS NULL
 		ORDER BY created_at DESC
 	`
 	err := r.db.SelectContext(ctx, &sessions, query, userID)
@@ -59,6 +85,9 @@ func (r *sessionRepository) GetByUserID(ctx context.Context, userID int) ([]*dom
 }
 
 func (r *sessionRepository) Delete(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "SessionRepository.Delete")
+	defer span.End()
+
 	query := `DELETE FROM sessions WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -75,6 +104,9 @@ func (r *sessionRepository) Delete(ctx context.Context, id int) error {
 }
 
 func (r *sessionRepository) DeleteByToken(ctx context.Context, token string) error {
+	ctx, span := startSpan(ctx, "SessionRepository.DeleteByToken")
+	defer span.End()
+
 	query := `DELETE FROM sessions WHERE token = $1`
 	result, err := r.db.ExecContext(ctx, query, token)
 	if err != nil {
@@ -91,13 +123,61 @@ func (r *sessionRepository) DeleteByToken(ctx context.Context, token string) err
 }
 
 func (r *sessionRepository) DeleteExpired(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "SessionRepository.DeleteExpired")
+	defer span.End()
+
 	query := `DELETE FROM sessions WHERE expires_at < CURRENT_TIMESTAMP`
 	_, err := r.db.ExecContext(ctx, query)
 	return err
 }
 
-func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID int) error {
-	query := `DELETE FROM sessions WHERE user_id = $1`
+// MarkRotated marks a live session as rotated.
+// Returns ErrSessionNotFound if it was already rotated or revoked, so concurrent refreshes can't both win.
+func (r *sessionRepository) MarkRotated(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "SessionRepository.MarkRotated")
+	defer span.End()
+
+	query := `
+		UPDATE sessions SET rotated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *sessionRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	ctx, span := startSpan(ctx, "SessionRepository.RevokeFamily")
+	defer span.End()
+
+	query := `
+		UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, familyID)
+	return err
+}
+
+// RevokeByUserID revokes every session family of the user. The rows stay
+// until DeleteExpired removes them, so a reused refresh token is still
+// recognised as revoked instead of unknown.
+func (r *sessionRepository) RevokeByUserID(ctx context.Context, userID int) error {
+	ctx, span := startSpan(ctx, "SessionRepository.RevokeByUserID")
+	defer span.End()
+
+	query := `
+		UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
 	_, err := r.db.ExecContext(ctx, query, userID)
 	return err
 }
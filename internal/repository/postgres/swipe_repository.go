@@ -3,7 +3,9 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/domain"
 	"github.com/gdugdh24/mpit2026-backend/internal/repository"
@@ -11,7 +13,7 @@ import (
 )
 
 type swipeRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func NewSwipeRepository(db *sqlx.DB) repository.SwipeRepository {
@@ -19,24 +21,46 @@ func NewSwipeRepository(db *sqlx.DB) repository.SwipeRepository {
 }
 
 func (r *swipeRepository) Create(ctx context.Context, swipe *domain.Swipe) error {
+	ctx, span := startSpan(ctx, "SwipeRepository.Create")
+	defer span.End()
+
 	query := `
-		INSERT INTO swipes (swiper_id, swiped_id, is_like)
-		VALUES ($1, $2, $3)
+		INSERT INTO swipes (swiper_id, swiped_id, is_like, direction)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ON CONSTRAINT unique_swipe DO NOTHING
 		RETURNING id, created_at
 	`
-	return r.db.QueryRowContext(
+	err := r.db.QueryRowContext(
 		ctx, query,
-		swipe.SwiperID, swipe.SwipedID, swipe.IsLike,
+		swipe.SwiperID, swipe.SwipedID, swipe.IsLike, swipe.Direction,
 	).Scan(&swipe.ID, &swipe.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrSwipeAlreadyExists
+	}
+	return err
+}
+
+func (r *swipeRepository) LockPair(ctx context.Context, user1ID, user2ID int) error {
+	ctx, span := startSpan(ctx, "SwipeRepository.LockPair")
+	defer span.End()
+
+	if user1ID > user2ID {
+		user1ID, user2ID = user2ID, user1ID
+	}
+	_, err := r.db.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, $2)`, user1ID, user2ID)
+	return err
 }
 
 func (r *swipeRepository) GetByID(ctx context.Context, id int) (*domain.Swipe, error) {
+	ctx, span := startSpan(ctx, "SwipeRepository.GetByID")
+	defer span.End()
+
 	var swipe domain.Swipe
 	query := `SELECT * FROM swipes WHERE id = $1`
 	err := r.db.GetContext(ctx, &swipe, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrSwipeAlreadyExists
+			return nil, domain.ErrSwipeNotFound
 		}
 		return nil, err
 	}
@@ -44,6 +68,9 @@ func (r *swipeRepository) GetByID(ctx context.Context, id int) (*domain.Swipe, e
 }
 
 func (r *swipeRepository) GetByUsers(ctx context.Context, swiperID, swipedID int) (*domain.Swipe, error) {
+	ctx, span := startSpan(ctx, "SwipeRepository.GetByUsers")
+	defer span.End()
+
 	var swipe domain.Swipe
 	query := `SELECT * FROM swipes WHERE swiper_id = $1 AND swiped_id = $2`
 	err := r.db.GetContext(ctx, &swipe, query, swiperID, swipedID)
@@ -57,6 +84,9 @@ func (r *swipeRepository) GetByUsers(ctx context.Context, swiperID, swipedID int
 }
 
 func (r *swipeRepository) GetUserSwipes(ctx context.Context, userID int, limit, offset int) ([]*domain.Swipe, error) {
+	ctx, span := startSpan(ctx, "SwipeRepository.GetUserSwipes")
+	defer span.End()
+
 	var swipes []*domain.Swipe
 	query := `
 		SELECT * FROM swipes
@@ -69,11 +99,23 @@ func (r *swipeRepository) GetUserSwipes(ctx context.Context, userID int, limit,
 }
 
 func (r *swipeRepository) GetLikesReceived(ctx context.Context, userID int, limit, offset int) ([]*domain.Swipe, error) {
+	ctx, span := startSpan(ctx, "SwipeRepository.GetLikesReceived")
+	defer span.End()
+
 	var swipes []*domain.Swipe
 	query := `
-		SELECT * FROM swipes
+		SELECT * FROM swipes s
 		WHERE swiped_id = $1 AND is_like = true
-		ORDER BY created_at DESC
+		AND NOT EXISTS (
+			SELECT 1 FROM users u
+			WHERE u.id = s.swiper_id AND (u.is_banned OR u.is_shadow_banned)
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM blocks b
+			WHERE (b.blocker_id = $1 AND b.blocked_id = s.swiper_id)
+			   OR (b.blocker_id = s.swiper_id AND b.blocked_id = $1)
+		)
+		ORDER BY (direction = 'super') DESC, created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 	err := r.db.SelectContext(ctx, &swipes, query, userID, limit, offset)
@@ -81,6 +123,9 @@ func (r *swipeRepository) GetLikesReceived(ctx context.Context, userID int, limi
 }
 
 func (r *swipeRepository) CheckMutualLike(ctx context.Context, user1ID, user2ID int) (bool, error) {
+	ctx, span := startSpan(ctx, "SwipeRepository.CheckMutualLike")
+	defer span.End()
+
 	var count int
 	query := `
 		SELECT COUNT(*) FROM swipes
@@ -93,3 +138,85 @@ func (r *swipeRepository) CheckMutualLike(ctx context.Context, user1ID, user2ID
 	}
 	return count == 2, nil
 }
+
+func (r *swipeRepository) GetLatestBySwiper(ctx context.Context, swiperID int) (*domain.Swipe, error) {
+	ctx, span := startSpan(ctx, "SwipeRepository.GetLatestBySwiper")
+	defer span.End()
+
+	var swipe domain.Swipe
+	query := `
+		SELECT * FROM swipes
+		WHERE swiper_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+	err := r.db.GetContext(ctx, &swipe, query, swiperID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSwipeNotFound
+		}
+		return nil, err
+	}
+	return &swipe, nil
+}
+
+func (r *swipeRepository) SetPrefSnapshot(ctx context.Context, id int, snapshot json.RawMessage) error {
+	ctx, span := startSpan(ctx, "SwipeRepository.SetPrefSnapshot")
+	defer span.End()
+
+	query := `UPDATE swipes SET pref_snapshot = $1 WHERE id = $2`
+	result, err := r.db.ExecContext(ctx, query, []byte(snapshot), id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrSwipeNotFound
+	}
+	return nil
+}
+
+func (r *swipeRepository) Delete(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "SwipeRepository.Delete")
+	defer span.End()
+
+	query := `DELETE FROM swipes WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrSwipeNotFound
+	}
+	return nil
+}
+
+func (r *swipeRepository) DeleteDislikes(ctx context.Context, swiperID int, before *time.Time) (int, error) {
+	ctx, span := startSpan(ctx, "SwipeRepository.DeleteDislikes")
+	defer span.End()
+
+	query := `
+		DELETE FROM swipes
+		WHERE swiper_id = $1 AND is_like = false
+		AND ($2::timestamptz IS NULL OR created_at < $2)
+	`
+	result, err := r.db.ExecContext(ctx, query, swiperID, before)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rows), nil
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+// dbtx is implemented by both *sqlx.DB and *sqlx.Tx, so a repository can run
+// standalone or inside a unit of work
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+type unitOfWork struct {
+	db *sqlx.DB
+}
+
+func NewUnitOfWork(db *sqlx.DB) repository.UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos *repository.TxRepositories) error) error {
+	// Repository spans of the transaction become children of this one
+	ctx, span := startSpan(ctx, "UnitOfWork.Do")
+	defer span.End()
+
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	repos := &repository.TxRepositories{
+		Swipes:   &swipeRepository{db: tx},
+		Matches:  &matchRepository{db: tx},
+		Jobs:     &jobRepository{db: tx},
+		Sessions: &sessionRepository{db: tx},
+		Quotas:   &quotaRepository{db: tx},
+		Users:    &userRepository{db: tx},
+		Reports:  &reportRepository{db: tx},
+		Audit:    &auditRepository{db: tx},
+		Profiles: &profileRepository{db: tx},
+		Blocks:   &blockRepository{db: tx},
+	}
+
+	if err := fn(repos); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -9,10 +9,11 @@ import (
 	"github.com/gdugdh24/mpit2026-backend/internal/domain"
 	"github.com/gdugdh24/mpit2026-backend/internal/repository"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type userRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func NewUserRepository(db *sqlx.DB) repository.UserRepository {
@@ -20,19 +21,26 @@ func NewUserRepository(db *sqlx.DB) repository.UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	ctx, span := startSpan(ctx, "UserRepository.Create")
+	defer span.End()
+
 	query := `
-		INSERT INTO users (vk_id, vk_access_token, vk_token_expires_at, gender, birth_date, is_verified, is_online)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, created_at, updated_at
+		INSERT INTO users (vk_id, vk_access_token, vk_token_expires_at, gender, birth_date, is_verified, is_online, is_synthetic)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, timezone, role, created_at, updated_at
 	`
 	return r.db.QueryRowContext(
 		ctx, query,
 		user.VKID, user.VKAccessToken, user.VKTokenExpiresAt,
 		user.Gender, user.BirthDate, user.IsVerified, user.IsOnline,
-	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+		user.IsSynthetic,
+	).Scan(&user.ID, &user.Timezone, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id int) (*domain.User, error) {
+	ctx, span := startSpan(ctx, "UserRepository.GetByID")
+	defer span.End()
+
 	var user domain.User
 	query := `SELECT * FROM users WHERE id = $1`
 	err := r.db.GetContext(ctx, &user, query, id)
@@ -46,6 +54,9 @@ func (r *userRepository) GetByID(ctx context.Context, id int) (*domain.User, err
 }
 
 func (r *userRepository) GetByVKID(ctx context.Context, vkID int) (*domain.User, error) {
+	ctx, span := startSpan(ctx, "UserRepository.GetByVKID")
+	defer span.End()
+
 	var user domain.User
 	query := `SELECT * FROM users WHERE vk_id = $1`
 	err := r.db.GetContext(ctx, &user, query, vkID)
@@ -59,23 +70,30 @@ func (r *userRepository) GetByVKID(ctx context.Context, vkID int) (*domain.User,
 }
 
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	ctx, span := startSpan(ctx, "UserRepository.Update")
+	defer span.End()
+
 	query := `
 		UPDATE users
 		SET vk_access_token = $1, vk_token_expires_at = $2, gender = $3,
 		    birth_date = $4, is_verified = $5, is_online = $6,
-		    last_online_at = $7, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $8
-		RETURNING updated_at
+		    last_online_at = $7, timezone = COALESCE(NULLIF($8, ''), timezone),
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $9
+		RETURNING timezone, updated_at
 	`
 	return r.db.QueryRowContext(
 		ctx, query,
 		user.VKAccessToken, user.VKTokenExpiresAt, user.Gender,
 		user.BirthDate, user.IsVerified, user.IsOnline,
-		user.LastOnlineAt, user.ID,
-	).Scan(&user.UpdatedAt)
+		user.LastOnlineAt, user.Timezone, user.ID,
+	).Scan(&user.Timezone, &user.UpdatedAt)
 }
 
 func (r *userRepository) Delete(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "UserRepository.Delete")
+	defer span.End()
+
 	query := `DELETE FROM users WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -92,6 +110,9 @@ func (r *userRepository) Delete(ctx context.Context, id int) error {
 }
 
 func (r *userRepository) UpdateOnlineStatus(ctx context.Context, userID int, isOnline bool) error {
+	ctx, span := startSpan(ctx, "UserRepository.UpdateOnlineStatus")
+	defer span.End()
+
 	now := time.Now()
 	query := `
 		UPDATE users
@@ -113,6 +134,9 @@ func (r *userRepository) UpdateOnlineStatus(ctx context.Context, userID int, isO
 }
 
 func (r *userRepository) GetOnlineUsers(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+	ctx, span := startSpan(ctx, "UserRepository.GetOnlineUsers")
+	defer span.End()
+
 	var users []*domain.User
 	query := `
 		SELECT * FROM users
@@ -123,3 +147,51 @@ func (r *userRepository) GetOnlineUsers(ctx context.Context, limit, offset int)
 	err := r.db.SelectContext(ctx, &users, query, limit, offset)
 	return users, err
 }
+
+func (r *userRepository) SetBanned(ctx context.Context, userID int, banned bool) error {
+	ctx, span := startSpan(ctx, "UserRepository.SetBanned")
+	defer span.End()
+
+	return r.setFlag(ctx, "is_banned", userID, banned)
+}
+
+func (r *userRepository) SetShadowBanned(ctx context.Context, userID int, shadowBanned bool) error {
+	ctx, span := startSpan(ctx, "UserRepository.SetShadowBanned")
+	defer span.End()
+
+	return r.setFlag(ctx, "is_shadow_banned", userID, shadowBanned)
+}
+
+func (r *userRepository) GetHiddenIDs(ctx context.Context, userIDs []int) ([]int, error) {
+	ctx, span := startSpan(ctx, "UserRepository.GetHiddenIDs")
+	defer span.End()
+
+	ids := []int{}
+	if len(userIDs) == 0 {
+		return ids, nil
+	}
+
+	query := `SELECT id FROM users WHERE id = ANY($1) AND (is_banned OR is_shadow_banned)`
+	err := r.db.SelectContext(ctx, &ids, query, pq.Array(userIDs))
+	return ids, err
+}
+
+// setFlag updates one of the moderation flags, column is never user input
+func (r *userRepository) setFlag(ctx context.Context, column string, userID int, value bool) error {
+	ctx, span := startSpan(ctx, "UserRepository.setFlag")
+	defer span.End()
+
+	query := `UPDATE users SET ` + column + ` = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+	result, err := r.db.ExecContext(ctx, query, value, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
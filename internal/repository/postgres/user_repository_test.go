@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/testutil/pgtest"
+)
+
+func TestUserGetHiddenIDs(t *testing.T) {
+	db := pgtest.Open(t)
+	ctx := context.Background()
+	users := NewUserRepository(db)
+
+	visible := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+	banned := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+	shadowBanned := pgtest.SeedUser(t, db, string(domain.GenderFemale), "Тест")
+	if err := users.SetBanned(ctx, banned, true); err != nil {
+		t.Fatalf("ban: %v", err)
+	}
+	if err := users.SetShadowBanned(ctx, shadowBanned, true); err != nil {
+		t.Fatalf("shadow ban: %v", err)
+	}
+
+	hidden, err := users.GetHiddenIDs(ctx, []int{visible, banned, shadowBanned})
+	if err != nil {
+		t.Fatalf("get hidden ids: %v", err)
+	}
+	got := map[int]bool{}
+	for _, id := range hidden {
+		got[id] = true
+	}
+	if len(hidden) != 2 || !got[banned] || !got[shadowBanned] {
+		t.Fatalf("hidden = %v, want %d and %d", hidden, banned, shadowBanned)
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/domain"
 )
@@ -10,8 +11,34 @@ type ProfileRepository interface {
 	Create(ctx context.Context, profile *domain.Profile) error
 	GetByID(ctx context.Context, id int) (*domain.Profile, error)
 	GetByUserID(ctx context.Context, userID int) (*domain.Profile, error)
+	// GetByUserIDForUpdate locks the profile row, for use in a unit of work
+	GetByUserIDForUpdate(ctx context.Context, userID int) (*domain.Profile, error)
 	Update(ctx context.Context, profile *domain.Profile) error
 	Delete(ctx context.Context, id int) error
 	UpdateOnboardingStatus(ctx context.Context, userID int, isComplete bool) error
 	SearchProfiles(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*domain.Profile, error)
+	GetFeedCandidates(ctx context.Context, filter CandidateFilter) ([]*domain.Candidate, error)
+}
+
+// CandidateFilter describes feed candidates for a viewer.
+// Nil fields are not filtered on.
+type CandidateFilter struct {
+	// ViewerID is excluded together with everyone the viewer already swiped
+	// and everyone blocked in either direction
+	ViewerID int
+	// Genders the viewer is interested in, empty means any
+	Genders []domain.Gender
+	// Candidate preferences that accept the viewer, so the match is mutual
+	InterestedIn []domain.InterestedIn
+	City         *string
+	// Birth date window derived from the viewer's age preferences
+	BornAfter  *time.Time
+	BornBefore *time.Time
+	// Bounding box around the viewer, exact distance is checked by the caller.
+	// Candidates without location are not filtered out.
+	MinLat, MaxLat *float64
+	MinLon, MaxLon *float64
+
+	ExcludeSynthetic bool
+	Limit            int
 }
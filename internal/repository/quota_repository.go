@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+type QuotaRepository interface {
+	// Get returns the usage of the current window, nil if nothing was counted
+	// since the last reset
+	Get(ctx context.Context, userID int, direction domain.SwipeDirection, now time.Time) (*domain.SwipeQuota, error)
+	// Consume counts one swipe against the quota. When limit swipes are already
+	// counted in the current window nothing is counted and ErrQuotaExceeded is
+	// returned with the usage. limit 0 means unlimited. A window that is over
+	// is replaced by a new one closing at nextReset.
+	Consume(ctx context.Context, userID int, direction domain.SwipeDirection, limit int, now, nextReset time.Time) (*domain.SwipeQuota, error)
+}
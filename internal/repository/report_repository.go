@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+type ReportRepository interface {
+	Create(ctx context.Context, report *domain.Report) error
+	GetByID(ctx context.Context, id int) (*domain.Report, error)
+	// List returns reports oldest first, filtered by status when it is set
+	List(ctx context.Context, status *domain.ReportStatus, limit, offset int) ([]*domain.Report, error)
+	// Resolve closes a pending report, ErrReportResolved if it is already closed
+	Resolve(ctx context.Context, report *domain.Report) error
+}
@@ -8,10 +8,13 @@ import (
 
 type SessionRepository interface {
 	Create(ctx context.Context, session *domain.Session) error
+	GetByID(ctx context.Context, id int) (*domain.Session, error)
 	GetByToken(ctx context.Context, token string) (*domain.Session, error)
 	GetByUserID(ctx context.Context, userID int) ([]*domain.Session, error)
 	Delete(ctx context.Context, id int) error
 	DeleteByToken(ctx context.Context, token string) error
 	DeleteExpired(ctx context.Context) error
-	DeleteByUserID(ctx context.Context, userID int) error
+	MarkRotated(ctx context.Context, id int) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeByUserID(ctx context.Context, userID int) error
 }
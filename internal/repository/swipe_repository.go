@@ -2,15 +2,30 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/domain"
 )
 
 type SwipeRepository interface {
+	// Create returns ErrSwipeAlreadyExists if the user already swiped the other one
 	Create(ctx context.Context, swipe *domain.Swipe) error
+	// LockPair serializes swipes between two users until the transaction
+	// ends, it only has an effect inside a UnitOfWork
+	LockPair(ctx context.Context, user1ID, user2ID int) error
 	GetByID(ctx context.Context, id int) (*domain.Swipe, error)
 	GetByUsers(ctx context.Context, swiperID, swipedID int) (*domain.Swipe, error)
 	GetUserSwipes(ctx context.Context, userID int, limit, offset int) ([]*domain.Swipe, error)
+	// GetLikesReceived returns likes with super-likes first, skipping blocked
+	// and banned users
 	GetLikesReceived(ctx context.Context, userID int, limit, offset int) ([]*domain.Swipe, error)
 	CheckMutualLike(ctx context.Context, user1ID, user2ID int) (bool, error)
+	// GetLatestBySwiper returns the most recent swipe made by the user
+	GetLatestBySwiper(ctx context.Context, swiperID int) (*domain.Swipe, error)
+	SetPrefSnapshot(ctx context.Context, id int, snapshot json.RawMessage) error
+	Delete(ctx context.Context, id int) error
+	// DeleteDislikes removes the user's dislikes, only those created before
+	// the given time when it is set, and returns how many were deleted
+	DeleteDislikes(ctx context.Context, swiperID int, before *time.Time) (int, error)
 }
@@ -0,0 +1,24 @@
+package repository
+
+import "context"
+
+// TxRepositories are repositories bound to a single transaction
+type TxRepositories struct {
+	Swipes   SwipeRepository
+	Matches  MatchRepository
+	Sessions SessionRepository
+	Quotas   QuotaRepository
+	Users    UserRepository
+	Reports  ReportRepository
+	Audit    AuditRepository
+	Profiles ProfileRepository
+	Blocks   BlockRepository
+	// Jobs enqueued here only become visible if the transaction commits
+	Jobs JobRepository
+}
+
+// UnitOfWork runs a function inside one database transaction. The transaction
+// is committed when fn returns nil and rolled back otherwise.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(repos *TxRepositories) error) error
+}
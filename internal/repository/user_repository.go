@@ -14,4 +14,8 @@ type UserRepository interface {
 	Delete(ctx context.Context, id int) error
 	UpdateOnlineStatus(ctx context.Context, userID int, isOnline bool) error
 	GetOnlineUsers(ctx context.Context, limit, offset int) ([]*domain.User, error)
+	SetBanned(ctx context.Context, userID int, banned bool) error
+	SetShadowBanned(ctx context.Context, userID int, shadowBanned bool) error
+	// GetHiddenIDs returns which of userIDs are banned or shadow-banned
+	GetHiddenIDs(ctx context.Context, userIDs []int) ([]int, error)
 }
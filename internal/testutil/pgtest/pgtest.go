@@ -0,0 +1,64 @@
+// Package pgtest connects tests to a real PostgreSQL database. Tests using it
+// are skipped unless TEST_DATABASE_URL points to a migrated database.
+package pgtest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// Open connects to the database in TEST_DATABASE_URL and skips the test when
+// it is not set
+func Open(tb testing.TB) *sqlx.DB {
+	tb.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		tb.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		tb.Fatalf("connect to test database: %v", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	return db
+}
+
+// SeedUser inserts a synthetic user with a profile in city and returns the
+// user ID. Test users get negative VK IDs and are deleted with everything
+// referencing them when the test ends.
+func SeedUser(tb testing.TB, db *sqlx.DB, gender, city string) int {
+	tb.Helper()
+	ctx := context.Background()
+
+	var userID int
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO users (vk_id, gender, birth_date, is_synthetic)
+		SELECT LEAST(COALESCE(MIN(vk_id), 0), 0) - 1, $1, DATE '1995-06-15', true
+		FROM users WHERE vk_id < 0
+		RETURNING id
+	`, gender).Scan(&userID)
+	if err != nil {
+		tb.Fatalf("seed user: %v", err)
+	}
+	tb.Cleanup(func() {
+		if _, err := db.ExecContext(context.Background(), `DELETE FROM users WHERE id = $1`, userID); err != nil {
+			tb.Errorf("delete seeded user %d: %v", userID, err)
+		}
+	})
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO profiles (user_id, display_name, city, interests, is_onboarding_complete)
+		VALUES ($1, 'Test', $2, ARRAY['музыка', 'кино'], true)
+	`, userID, city)
+	if err != nil {
+		tb.Fatalf("seed profile: %v", err)
+	}
+
+	return userID
+}
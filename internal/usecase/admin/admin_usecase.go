@@ -0,0 +1,370 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/notification"
+)
+
+const (
+	targetUser   = "user"
+	targetReport = "report"
+	targetAudit  = "audit_log"
+)
+
+type AdminUseCase struct {
+	userRepo    repository.UserRepository
+	profileRepo repository.ProfileRepository
+	swipeRepo   repository.SwipeRepository
+	matchRepo   repository.MatchRepository
+	reportRepo  repository.ReportRepository
+	auditRepo   repository.AuditRepository
+	uow         repository.UnitOfWork
+	notifier    notification.Notifier
+	log         *slog.Logger
+}
+
+func NewAdminUseCase(
+	userRepo repository.UserRepository,
+	profileRepo repository.ProfileRepository,
+	swipeRepo repository.SwipeRepository,
+	matchRepo repository.MatchRepository,
+	reportRepo repository.ReportRepository,
+	auditRepo repository.AuditRepository,
+	uow repository.UnitOfWork,
+	notifier notification.Notifier,
+	log *slog.Logger,
+) *AdminUseCase {
+	return &AdminUseCase{
+		userRepo:    userRepo,
+		profileRepo: profileRepo,
+		swipeRepo:   swipeRepo,
+		matchRepo:   matchRepo,
+		reportRepo:  reportRepo,
+		auditRepo:   auditRepo,
+		uow:         uow,
+		notifier:    notifier,
+		log:         log,
+	}
+}
+
+// Actor is the admin or moderator performing an action
+type Actor struct {
+	ID   int
+	Role domain.UserRole
+	IP   string
+}
+
+// UserDetails is the admin view of a user, including moderation flags
+type UserDetails struct {
+	User           *domain.User    `json:"user"`
+	Profile        *domain.Profile `json:"profile,omitempty"`
+	IsBanned       bool            `json:"is_banned"`
+	IsShadowBanned bool            `json:"is_shadow_banned"`
+}
+
+// ModerationRequest carries the reason for a ban or shadow ban
+type ModerationRequest struct {
+	Reason string `json:"reason" binding:"max=1000"`
+}
+
+// ResolveReportRequest closes a pending report
+type ResolveReportRequest struct {
+	Status     domain.ReportStatus `json:"status" binding:"required,oneof=resolved dismissed"`
+	Resolution *string             `json:"resolution" binding:"omitempty,max=1000"`
+}
+
+// SearchByVKID finds a user by their VK ID
+func (uc *AdminUseCase) SearchByVKID(ctx context.Context, actor Actor, vkID int) (*UserDetails, error) {
+	user, err := uc.userRepo.GetByVKID(ctx, vkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.record(ctx, uc.auditRepo, actor, domain.AuditUserSearch, targetUser, &user.ID, map[string]interface{}{
+		"vk_id": vkID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return uc.details(ctx, user)
+}
+
+// GetUser returns a user with their profile
+func (uc *AdminUseCase) GetUser(ctx context.Context, actor Actor, userID int) (*UserDetails, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.record(ctx, uc.auditRepo, actor, domain.AuditUserView, targetUser, &userID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.details(ctx, user)
+}
+
+// GetUserSwipes returns swipes made by the user, newest first
+func (uc *AdminUseCase) GetUserSwipes(ctx context.Context, actor Actor, userID, limit, offset int) ([]*domain.Swipe, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.record(ctx, uc.auditRepo, actor, domain.AuditUserSwipesView, targetUser, &userID, nil); err != nil {
+		return nil, err
+	}
+
+	swipes, err := uc.swipeRepo.GetUserSwipes(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get swipes: %w", err)
+	}
+
+	return swipes, nil
+}
+
+// GetUserMatches returns the user's matches, including inactive ones
+func (uc *AdminUseCase) GetUserMatches(ctx context.Context, actor Actor, userID, limit, offset int) ([]*domain.Match, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.record(ctx, uc.auditRepo, actor, domain.AuditUserMatchesView, targetUser, &userID, nil); err != nil {
+		return nil, err
+	}
+
+	matches, err := uc.matchRepo.GetUserMatches(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	return matches, nil
+}
+
+// roleRank orders roles, a moderator can only act on users ranked below them
+var roleRank = map[domain.UserRole]int{
+	domain.RoleUser:      0,
+	domain.RoleModerator: 1,
+	domain.RoleAdmin:     2,
+}
+
+// checkOutranks refuses an action on a user whose role is not below the actor's
+func checkOutranks(actor Actor, target *domain.User) error {
+	if roleRank[target.Role] >= roleRank[actor.Role] {
+		return domain.ErrTargetOutranks
+	}
+	return nil
+}
+
+// SetBanned bans or unbans a user. A ban also revokes all of the user's
+// sessions, so their access tokens stop working immediately, and ends their
+// matches. Unbanning doesn't bring the matches back.
+// The change and its audit entry commit together.
+func (uc *AdminUseCase) SetBanned(ctx context.Context, actor Actor, userID int, banned bool, req *ModerationRequest) error {
+	if banned && userID == actor.ID {
+		return domain.ErrForbidden
+	}
+
+	action := domain.AuditUserUnban
+	if banned {
+		action = domain.AuditUserBan
+	}
+
+	return uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		target, err := repos.Users.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if err := checkOutranks(actor, target); err != nil {
+			return err
+		}
+
+		if err := repos.Users.SetBanned(ctx, userID, banned); err != nil {
+			return err
+		}
+
+		if banned {
+			if err := repos.Sessions.RevokeByUserID(ctx, userID); err != nil {
+				return fmt.Errorf("failed to revoke sessions: %w", err)
+			}
+			if _, err := repos.Matches.DeactivateByUser(ctx, userID); err != nil {
+				return fmt.Errorf("failed to end matches: %w", err)
+			}
+		}
+
+		return uc.record(ctx, repos.Audit, actor, action, targetUser, &userID, map[string]interface{}{
+			"reason": req.Reason,
+		})
+	})
+}
+
+// SetShadowBanned hides a user from everyone else, the user is not told
+func (uc *AdminUseCase) SetShadowBanned(ctx context.Context, actor Actor, userID int, shadowBanned bool, req *ModerationRequest) error {
+	action := domain.AuditUserShadowUnban
+	if shadowBanned {
+		action = domain.AuditUserShadowBan
+	}
+
+	return uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		target, err := repos.Users.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if err := checkOutranks(actor, target); err != nil {
+			return err
+		}
+
+		if err := repos.Users.SetShadowBanned(ctx, userID, shadowBanned); err != nil {
+			return err
+		}
+
+		return uc.record(ctx, repos.Audit, actor, action, targetUser, &userID, map[string]interface{}{
+			"reason": req.Reason,
+		})
+	})
+}
+
+// ExpireSessions logs the user out everywhere
+func (uc *AdminUseCase) ExpireSessions(ctx context.Context, actor Actor, userID int) error {
+	target, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := checkOutranks(actor, target); err != nil {
+		return err
+	}
+
+	return uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		if err := repos.Sessions.RevokeByUserID(ctx, userID); err != nil {
+			return fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+
+		return uc.record(ctx, repos.Audit, actor, domain.AuditSessionsExpire, targetUser, &userID, nil)
+	})
+}
+
+// ListReports returns the moderation queue, oldest first
+func (uc *AdminUseCase) ListReports(ctx context.Context, actor Actor, status *domain.ReportStatus, limit, offset int) ([]*domain.Report, error) {
+	details := map[string]interface{}{}
+	if status != nil {
+		details["status"] = *status
+	}
+	if err := uc.record(ctx, uc.auditRepo, actor, domain.AuditReportsView, targetReport, nil, details); err != nil {
+		return nil, err
+	}
+
+	reports, err := uc.reportRepo.List(ctx, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// ResolveReport closes a pending report as resolved or dismissed and tells
+// the reporter the outcome
+func (uc *AdminUseCase) ResolveReport(ctx context.Context, actor Actor, reportID int, req *ResolveReportRequest) (*domain.Report, error) {
+	report, err := uc.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Status = req.Status
+	report.Resolution = req.Resolution
+	report.ResolvedBy = &actor.ID
+	err = uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		if err := repos.Reports.Resolve(ctx, report); err != nil {
+			return err
+		}
+
+		return uc.record(ctx, repos.Audit, actor, domain.AuditReportResolve, targetReport, &reportID, map[string]interface{}{
+			"status":      req.Status,
+			"reported_id": report.ReportedID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The report is already closed, a lost notification is only logged
+	if err := uc.notifier.Notify(ctx, report.ReporterID, domain.NotificationModerationResult, &notification.ModerationResultPayload{
+		ReportID: report.ID,
+		Status:   report.Status,
+		Reason:   report.Resolution,
+	}); err != nil {
+		uc.log.WarnContext(ctx, "failed to notify reporter", "report_id", report.ID, "error", err)
+	}
+
+	return report, nil
+}
+
+// ListAuditLog returns audit entries, newest first
+func (uc *AdminUseCase) ListAuditLog(ctx context.Context, actor Actor, filter repository.AuditFilter) ([]*domain.AuditEntry, error) {
+	details := map[string]interface{}{}
+	if filter.ActorID != nil {
+		details["actor_id"] = *filter.ActorID
+	}
+	if filter.TargetType != "" {
+		details["target_type"] = filter.TargetType
+	}
+	if filter.TargetID != nil {
+		details["target_id"] = *filter.TargetID
+	}
+	if err := uc.record(ctx, uc.auditRepo, actor, domain.AuditLogView, targetAudit, nil, details); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (uc *AdminUseCase) details(ctx context.Context, user *domain.User) (*UserDetails, error) {
+	profile, err := uc.profileRepo.GetByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrProfileNotFound) {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	return &UserDetails{
+		User:           user,
+		Profile:        profile,
+		IsBanned:       user.IsBanned,
+		IsShadowBanned: user.IsShadowBanned,
+	}, nil
+}
+
+// record writes an audit entry to audit, the transaction's repository for
+// changes. Actions fail when the entry can't be written, so nothing an admin
+// does goes unlogged.
+func (uc *AdminUseCase) record(ctx context.Context, audit repository.AuditRepository, actor Actor, action domain.AuditAction, targetType string, targetID *int, details interface{}) error {
+	entry := &domain.AuditEntry{
+		ActorID:    actor.ID,
+		ActorRole:  actor.Role,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+	}
+	if details != nil {
+		raw, err := json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit details: %w", err)
+		}
+		entry.Details = raw
+	}
+	if actor.IP != "" {
+		entry.IPAddress = &actor.IP
+	}
+
+	if err := audit.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+)
+
+// memoryUnitOfWork runs fn against in-memory repositories without a transaction
+type memoryUnitOfWork struct {
+	repos *repository.TxRepositories
+}
+
+func (u memoryUnitOfWork) Do(_ context.Context, fn func(repos *repository.TxRepositories) error) error {
+	return fn(u.repos)
+}
+
+type memoryUsers struct {
+	repository.UserRepository
+	users map[int]*domain.User
+}
+
+func (r *memoryUsers) GetByID(_ context.Context, id int) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *memoryUsers) SetBanned(_ context.Context, userID int, banned bool) error {
+	r.users[userID].IsBanned = banned
+	return nil
+}
+
+func (r *memoryUsers) SetShadowBanned(_ context.Context, userID int, shadowBanned bool) error {
+	r.users[userID].IsShadowBanned = shadowBanned
+	return nil
+}
+
+type memorySessions struct {
+	repository.SessionRepository
+	revoked []int
+}
+
+func (r *memorySessions) RevokeByUserID(_ context.Context, userID int) error {
+	r.revoked = append(r.revoked, userID)
+	return nil
+}
+
+type memoryMatches struct {
+	repository.MatchRepository
+	ended []int
+}
+
+func (r *memoryMatches) DeactivateByUser(_ context.Context, userID int) (int, error) {
+	r.ended = append(r.ended, userID)
+	return 1, nil
+}
+
+type memoryAudit struct {
+	repository.AuditRepository
+	entries []*domain.AuditEntry
+}
+
+func (r *memoryAudit) Create(_ context.Context, entry *domain.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+type testRepos struct {
+	users    *memoryUsers
+	sessions *memorySessions
+	matches  *memoryMatches
+	audit    *memoryAudit
+}
+
+func newTestAdminUseCase() (*AdminUseCase, *testRepos) {
+	repos := &testRepos{
+		users: &memoryUsers{users: map[int]*domain.User{
+			1: {ID: 1, Role: domain.RoleAdmin},
+			2: {ID: 2, Role: domain.RoleAdmin},
+			3: {ID: 3, Role: domain.RoleModerator},
+			4: {ID: 4, Role: domain.RoleModerator},
+			5: {ID: 5, Role: domain.RoleUser},
+		}},
+		sessions: &memorySessions{},
+		matches:  &memoryMatches{},
+		audit:    &memoryAudit{},
+	}
+	uow := memoryUnitOfWork{repos: &repository.TxRepositories{
+		Users:    repos.users,
+		Sessions: repos.sessions,
+		Matches:  repos.matches,
+		Audit:    repos.audit,
+	}}
+
+	uc := NewAdminUseCase(
+		repos.users,
+		nil,
+		nil,
+		repos.matches,
+		nil,
+		repos.audit,
+		uow,
+		nil,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	return uc, repos
+}
+
+func TestModerationActionsRespectRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   Actor
+		target  int
+		wantErr error
+	}{
+		{name: "admin on user", actor: Actor{ID: 1, Role: domain.RoleAdmin}, target: 5},
+		{name: "admin on moderator", actor: Actor{ID: 1, Role: domain.RoleAdmin}, target: 3},
+		{name: "admin on admin", actor: Actor{ID: 1, Role: domain.RoleAdmin}, target: 2, wantErr: domain.ErrTargetOutranks},
+		{name: "moderator on user", actor: Actor{ID: 3, Role: domain.RoleModerator}, target: 5},
+		{name: "moderator on moderator", actor: Actor{ID: 3, Role: domain.RoleModerator}, target: 4, wantErr: domain.ErrTargetOutranks},
+		{name: "moderator on admin", actor: Actor{ID: 3, Role: domain.RoleModerator}, target: 1, wantErr: domain.ErrTargetOutranks},
+		{name: "missing user", actor: Actor{ID: 1, Role: domain.RoleAdmin}, target: 42, wantErr: domain.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uc, repos := newTestAdminUseCase()
+
+			err := uc.SetShadowBanned(ctx, tt.actor, tt.target, true, &ModerationRequest{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("shadow ban: got %v, want %v", err, tt.wantErr)
+			}
+			if err := uc.SetBanned(ctx, tt.actor, tt.target, true, &ModerationRequest{}); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ban: got %v, want %v", err, tt.wantErr)
+			}
+			if err := uc.ExpireSessions(ctx, tt.actor, tt.target); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expire sessions: got %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if target, ok := repos.users.users[tt.target]; ok && (target.IsBanned || target.IsShadowBanned) {
+					t.Fatalf("refused action still changed user %d: %+v", tt.target, target)
+				}
+				if len(repos.sessions.revoked) != 0 || len(repos.audit.entries) != 0 {
+					t.Fatal("refused action revoked sessions or wrote the audit log")
+				}
+			}
+		})
+	}
+}
+
+func TestBanEndsMatches(t *testing.T) {
+	ctx := context.Background()
+	uc, repos := newTestAdminUseCase()
+	admin := Actor{ID: 1, Role: domain.RoleAdmin}
+
+	if err := uc.SetBanned(ctx, admin, 5, true, &ModerationRequest{Reason: "spam"}); err != nil {
+		t.Fatalf("ban: %v", err)
+	}
+	if !repos.users.users[5].IsBanned {
+		t.Fatal("user is not banned")
+	}
+	if len(repos.matches.ended) != 1 || repos.matches.ended[0] != 5 {
+		t.Fatalf("ended matches of %v, want [5]", repos.matches.ended)
+	}
+	if len(repos.sessions.revoked) != 1 || repos.sessions.revoked[0] != 5 {
+		t.Fatalf("revoked sessions of %v, want [5]", repos.sessions.revoked)
+	}
+
+	// Unbanning leaves the ended matches alone
+	if err := uc.SetBanned(ctx, admin, 5, false, &ModerationRequest{}); err != nil {
+		t.Fatalf("unban: %v", err)
+	}
+	if len(repos.matches.ended) != 1 {
+		t.Fatalf("unban touched matches: %v", repos.matches.ended)
+	}
+}
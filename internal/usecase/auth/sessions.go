@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/pkg/useragent"
+)
+
+// SessionResponse represents an active login (device) of the user
+type SessionResponse struct {
+	ID           int       `json:"id"`
+	Platform     string    `json:"platform"`
+	App          string    `json:"app"`
+	AppVersion   string    `json:"app_version"`
+	DeviceInfo   *string   `json:"device_info"`
+	IPAddress    *string   `json:"ip_address"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	IsCurrent    bool      `json:"is_current"`
+}
+
+// ListSessions returns the user's active sessions, marking the one the access token belongs to
+func (uc *VKAuthUseCase) ListSessions(ctx context.Context, userID int, accessToken string) ([]*SessionResponse, error) {
+	sessions, err := uc.sessionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	currentFamilyID := ""
+	if current, err := uc.sessionFromAccessToken(ctx, accessToken); err == nil {
+		currentFamilyID = current.FamilyID
+	}
+
+	responses := make([]*SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		deviceInfo := ""
+		if session.DeviceInfo != nil {
+			deviceInfo = *session.DeviceInfo
+		}
+		info := useragent.Parse(deviceInfo)
+
+		// A row is created on every refresh, so created_at is the last activity
+		responses = append(responses, &SessionResponse{
+			ID:           session.ID,
+			Platform:     info.Platform,
+			App:          info.App,
+			AppVersion:   info.AppVersion,
+			DeviceInfo:   session.DeviceInfo,
+			IPAddress:    session.IPAddress,
+			LastActiveAt: session.CreatedAt,
+			ExpiresAt:    session.ExpiresAt,
+			IsCurrent:    session.FamilyID == currentFamilyID,
+		})
+	}
+
+	return responses, nil
+}
+
+// RevokeSession revokes one of the user's sessions together with its refresh token family
+func (uc *VKAuthUseCase) RevokeSession(ctx context.Context, userID, sessionID int) error {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.UserID != userID {
+		return domain.ErrForbidden
+	}
+
+	if session.IsRevoked() {
+		return domain.ErrSessionNotFound
+	}
+
+	return uc.sessionRepo.RevokeFamily(ctx, session.FamilyID)
+}
+
+// LogoutAll revokes every session family of the user on every device
+func (uc *VKAuthUseCase) LogoutAll(ctx context.Context, userID int) error {
+	return uc.sessionRepo.RevokeByUserID(ctx, userID)
+}
+
+// sessionFromAccessToken returns the session an access token was issued for
+func (uc *VKAuthUseCase) sessionFromAccessToken(ctx context.Context, accessToken string) (*domain.Session, error) {
+	claims, err := uc.tokenManager.ValidateAccessToken(accessToken)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	return uc.sessionRepo.GetByID(ctx, claims.SessionID)
+}
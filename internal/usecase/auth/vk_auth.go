@@ -2,44 +2,54 @@ package auth
 
 import (
 	"context"
-	"crypto/hmac"
 	"crypto/sha256"
-	"encoding/base64"
 	"encoding/hex"
 	"fmt"
-	"sort"
-	"strings"
+	"log/slog"
 	"time"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/domain"
 	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/profile"
+	"github.com/gdugdh24/mpit2026-backend/pkg/jwt"
 	"github.com/gdugdh24/mpit2026-backend/pkg/vkapi"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 type VKAuthUseCase struct {
-	userRepo    repository.UserRepository
-	profileRepo repository.ProfileRepository
-	sessionRepo repository.SessionRepository
-	vkSecret    string
-	jwtSecret   string
-	vkAPIClient *vkapi.Client
+	userRepo     repository.UserRepository
+	profileRepo  repository.ProfileRepository
+	sessionRepo  repository.SessionRepository
+	uow          repository.UnitOfWork
+	vkVerifier   *vkapi.LaunchParamsVerifier
+	tokenManager *jwt.TokenManager
+	vkAPIClient  *vkapi.Client
+	vkImporter   profile.VKImporter
+	log          *slog.Logger
 }
 
 func NewVKAuthUseCase(
 	userRepo repository.UserRepository,
 	profileRepo repository.ProfileRepository,
 	sessionRepo repository.SessionRepository,
+	uow repository.UnitOfWork,
 	vkSecret string,
-	jwtSecret string,
+	vkAppID int,
+	vkLaunchParamsMaxAge time.Duration,
+	tokenManager *jwt.TokenManager,
+	vkAPIClient *vkapi.Client,
+	vkImporter profile.VKImporter,
+	log *slog.Logger,
 ) *VKAuthUseCase {
 	return &VKAuthUseCase{
-		userRepo:    userRepo,
-		profileRepo: profileRepo,
-		sessionRepo: sessionRepo,
-		vkSecret:    vkSecret,
-		jwtSecret:   jwtSecret,
-		vkAPIClient: vkapi.NewClient(),
+		userRepo:     userRepo,
+		profileRepo:  profileRepo,
+		sessionRepo:  sessionRepo,
+		uow:          uow,
+		vkVerifier:   vkapi.NewLaunchParamsVerifier(vkSecret, vkAppID, vkLaunchParamsMaxAge),
+		tokenManager: tokenManager,
+		vkAPIClient:  vkAPIClient,
+		vkImporter:   vkImporter,
+		log:          log,
 	}
 }
 
@@ -55,41 +65,43 @@ type VKLaunchParams struct {
 	Sign                    string `json:"sign"`
 }
 
+// TokenPair represents a short-lived access token and a rotating refresh token
+type TokenPair struct {
+	AccessToken      string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
 // AuthResponse represents the authentication response
 type AuthResponse struct {
-	Token     string       `json:"token"`
-	ExpiresAt time.Time    `json:"expires_at"`
+	*TokenPair
 	User      *domain.User `json:"user"`
 	IsNewUser bool         `json:"is_new_user"`
 }
 
 // AuthenticateVK authenticates user via VK Mini App launch params
 func (uc *VKAuthUseCase) AuthenticateVK(ctx context.Context, params map[string]string, accessToken, deviceInfo, ipAddress string) (*AuthResponse, error) {
-	// Verify VK signature
-	// ВРЕМЕННО ОТКЛЮЧЕНО для отладки
-	fmt.Println("⚠️  WARNING: VK signature verification is DISABLED for debugging")
-	// if err := uc.verifyVKSignature(params); err != nil {
-	// 	return nil, domain.ErrInvalidVKSignature
-	// }
+	// Verify VK signature, app ID and vk_ts freshness
+	if err := uc.vkVerifier.Verify(params); err != nil {
+		uc.log.WarnContext(ctx, "rejected VK launch params", "vk_user_id", params["vk_user_id"], "error", err)
+		return nil, domain.ErrInvalidVKSignature
+	}
 
 	vkID := 0
 	fmt.Sscanf(params["vk_user_id"], "%d", &vkID)
 	if vkID == 0 {
-		fmt.Println("❌ ERROR: vk_user_id is 0")
+		uc.log.WarnContext(ctx, "VK launch params without vk_user_id")
 		return nil, domain.ErrInvalidInput
 	}
 
-	fmt.Printf("📝 Fetching VK user info for ID: %d with access_token: %s...\n", vkID, accessToken[:20]+"...")
-
 	// Fetch user info from VK API
-	vkUserInfo, err := uc.vkAPIClient.GetUserInfo(accessToken, vkID)
+	vkUserInfo, err := uc.vkAPIClient.GetUserInfo(ctx, accessToken, vkID)
 	if err != nil {
-		fmt.Printf("❌ ERROR: Failed to fetch VK user info: %v\n", err)
+		uc.log.ErrorContext(ctx, "failed to fetch VK user info", "vk_id", vkID, "error", err)
 		return nil, fmt.Errorf("failed to fetch VK user info: %w", err)
 	}
 
-	fmt.Printf("✅ VK user info received: %s %s\n", vkUserInfo.FirstName, vkUserInfo.LastName)
-
 	// Try to get existing user
 	user, err := uc.userRepo.GetByVKID(ctx, vkID)
 	isNewUser := false
@@ -113,26 +125,30 @@ func (uc *VKAuthUseCase) AuthenticateVK(ctx context.Context, params map[string]s
 		}
 	}
 
+	if user.IsBanned {
+		return nil, domain.ErrUserBanned
+	}
+
 	// Update online status
 	if err := uc.userRepo.UpdateOnlineStatus(ctx, user.ID, true); err != nil {
 		return nil, fmt.Errorf("failed to update online status: %w", err)
 	}
 
 	// Create session
-	token, expiresAt, err := uc.createSession(ctx, user.ID, deviceInfo, ipAddress)
+	tokens, err := uc.createSession(ctx, uc.sessionRepo, user, "", deviceInfo, ipAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
 	return &AuthResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
+		TokenPair: tokens,
 		User:      user,
 		IsNewUser: isNewUser,
 	}, nil
 }
 
-// AuthenticateVKTest authenticates user for testing without signature verification
+// AuthenticateVKTest authenticates user for testing without signature verification.
+// Users created here are marked synthetic.
 func (uc *VKAuthUseCase) AuthenticateVKTest(ctx context.Context, params map[string]string, deviceInfo, ipAddress string) (*AuthResponse, error) {
 	// Skip signature verification for test endpoint
 
@@ -157,82 +173,36 @@ func (uc *VKAuthUseCase) AuthenticateVKTest(ctx context.Context, params map[stri
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	if user.IsBanned {
+		return nil, domain.ErrUserBanned
+	}
+
 	// Update online status
 	if err := uc.userRepo.UpdateOnlineStatus(ctx, user.ID, true); err != nil {
 		return nil, fmt.Errorf("failed to update online status: %w", err)
 	}
 
 	// Create session
-	token, expiresAt, err := uc.createSession(ctx, user.ID, deviceInfo, ipAddress)
+	tokens, err := uc.createSession(ctx, uc.sessionRepo, user, "", deviceInfo, ipAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
 	return &AuthResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
+		TokenPair: tokens,
 		User:      user,
 		IsNewUser: isNewUser,
 	}, nil
 }
 
-// verifyVKSignature verifies VK Mini App launch params signature
-func (uc *VKAuthUseCase) verifyVKSignature(params map[string]string) error {
-	sign := params["sign"]
-	if sign == "" {
-		fmt.Println("DEBUG: No sign parameter found")
-		return domain.ErrInvalidVKSignature
-	}
-
-	// Create query string from params (excluding sign and vk_access_token_settings)
-	// vk_access_token_settings is added by VK after signature generation
-	var keys []string
-	for k := range params {
-		if k != "sign" && k != "vk_access_token_settings" && strings.HasPrefix(k, "vk_") {
-			keys = append(keys, k)
-		}
-	}
-	sort.Strings(keys)
-
-	var queryString strings.Builder
-	for i, k := range keys {
-		if i > 0 {
-			queryString.WriteString("&")
-		}
-		queryString.WriteString(k)
-		queryString.WriteString("=")
-		// VK doesn't use URL encoding for signature verification
-		queryString.WriteString(params[k])
-	}
-
-	fmt.Printf("DEBUG: Query string: %s\n", queryString.String())
-	fmt.Printf("DEBUG: VK Secret length: %d\n", len(uc.vkSecret))
-
-	// Calculate HMAC-SHA256
-	h := hmac.New(sha256.New, []byte(uc.vkSecret))
-	h.Write([]byte(queryString.String()))
-	calculatedSign := base64.URLEncoding.EncodeToString(h.Sum(nil))
-	calculatedSign = strings.TrimRight(calculatedSign, "=")
-	calculatedSign = strings.ReplaceAll(calculatedSign, "+", "-")
-	calculatedSign = strings.ReplaceAll(calculatedSign, "/", "_")
-
-	fmt.Printf("DEBUG: Received sign: %s\n", sign)
-	fmt.Printf("DEBUG: Calculated sign: %s\n", calculatedSign)
-
-	if sign != calculatedSign {
-		return domain.ErrInvalidVKSignature
-	}
-
-	return nil
-}
-
-// createUserFromVK creates a new user from VK params
+// createUserFromVK creates a new synthetic user from test params
 func (uc *VKAuthUseCase) createUserFromVK(ctx context.Context, vkID int, params map[string]string) (*domain.User, error) {
 	// Parse gender from params
 	gender := domain.GenderMale
 	if g, ok := params["gender"]; ok {
-		if g == "female" {
-			gender = domain.GenderFemale
+		switch domain.Gender(g) {
+		case domain.GenderFemale, domain.GenderNonBinary:
+			gender = domain.Gender(g)
 		}
 	}
 
@@ -245,15 +215,41 @@ func (uc *VKAuthUseCase) createUserFromVK(ctx context.Context, vkID int, params
 	}
 
 	user := &domain.User{
-		VKID:       vkID,
-		Gender:     gender,
-		BirthDate:  birthDate,
-		IsVerified: false,
-		IsOnline:   true,
-    
+		VKID:        vkID,
+		Gender:      gender,
+		BirthDate:   birthDate,
+		IsVerified:  false,
+		IsOnline:    true,
+		IsSynthetic: true,
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// Auto-create profile for new user
+	displayName := params["first_name"]
+	if lastName, ok := params["last_name"]; ok && lastName != "" {
+		displayName = displayName + " " + lastName
+	}
+
+	profile := &domain.Profile{
+		UserID:       user.ID,
+		DisplayName:  displayName,
+		InterestedIn: domain.DefaultInterestedIn(user.Gender),
+	}
+
+	if err := uc.profileRepo.Create(ctx, profile); err != nil {
+		// Don't fail user creation if profile creation fails
+		uc.log.WarnContext(ctx, "failed to create profile", "user_id", user.ID, "error", err)
+	}
+
+	return user, nil
+}
+
 // createUserFromVKInfo creates a new user from VK API data
 func (uc *VKAuthUseCase) createUserFromVKInfo(ctx context.Context, vkInfo *vkapi.VKUserInfo, accessToken string) (*domain.User, error) {
-	// Parse gender
+	// Parse gender (VK only reports 1 = female, 2 = male, 0 = unset)
 	gender := domain.GenderMale
 	if vkInfo.Sex == 1 {
 		gender = domain.GenderFemale
@@ -286,98 +282,177 @@ func (uc *VKAuthUseCase) createUserFromVKInfo(ctx context.Context, vkInfo *vkapi
 		return nil, err
 	}
 
-	// Auto-create profile for new user
-	displayName := params["first_name"]
-	if lastName, ok := params["last_name"]; ok && lastName != "" {
-		displayName = displayName + " " + lastName
-	}
-
-	profile := &domain.Profile{
-		UserID:      user.ID,
-		DisplayName: displayName,
-	}
-
-	if err := uc.profileRepo.Create(ctx, profile); err != nil {
-		// Don't fail user creation if profile creation fails
-		fmt.Printf("Warning: failed to create profile for user %d: %v\n", user.ID, err)
+	// Create the profile from VK name, city and avatar. Don't fail signup if
+	// the import fails, it can be re-run via POST /profile/import-vk.
+	if _, err := uc.vkImporter.ImportVKInfo(ctx, user, vkInfo); err != nil {
+		uc.log.WarnContext(ctx, "failed to import VK profile", "user_id", user.ID, "error", err)
 	}
 
 	return user, nil
 }
 
-// createSession creates a new session and returns JWT token
-func (uc *VKAuthUseCase) createSession(ctx context.Context, userID int, deviceInfo, ipAddress string) (string, time.Time, error) {
-	expiresAt := time.Now().Add(24 * 7 * time.Hour) // 7 days
+// createSession stores a new refresh token session and issues a token pair
+// carrying the user's current role.
+// An empty familyID starts a new token family (new login).
+func (uc *VKAuthUseCase) createSession(ctx context.Context, sessions repository.SessionRepository, user *domain.User, familyID, deviceInfo, ipAddress string) (*TokenPair, error) {
+	userID := user.ID
+	role := string(user.Role)
 
-	// Generate JWT token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": userID,
-		"exp":     expiresAt.Unix(),
-		"iat":     time.Now().Unix(),
-	})
+	now := time.Now()
+	expiresAt := now.Add(uc.tokenManager.AccessExpiry())
+	refreshExpiresAt := now.Add(uc.tokenManager.RefreshExpiry())
 
-	tokenString, err := token.SignedString([]byte(uc.jwtSecret))
+	refreshToken, err := uc.tokenManager.GenerateRefreshToken(userID, role)
 	if err != nil {
-		return "", time.Time{}, err
+		return nil, err
 	}
 
 	// Create session in DB
 	session := &domain.Session{
 		UserID:     userID,
-		Token:      uc.hashToken(tokenString),
+		FamilyID:   familyID,
+		Token:      uc.hashToken(refreshToken),
 		DeviceInfo: &deviceInfo,
 		IPAddress:  &ipAddress,
-		ExpiresAt:  expiresAt,
+		ExpiresAt:  refreshExpiresAt,
+	}
+
+	if err := sessions.Create(ctx, session); err != nil {
+		return nil, err
 	}
 
-	if err := uc.sessionRepo.Create(ctx, session); err != nil {
-		return "", time.Time{}, err
+	// Access token is bound to the session so revoking the family logs it out
+	accessToken, err := uc.tokenManager.GenerateAccessToken(userID, session.ID, role)
+	if err != nil {
+		return nil, err
 	}
 
-	return tokenString, expiresAt, nil
+	return &TokenPair{
+		AccessToken:      accessToken,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
 }
 
-// VerifyToken verifies JWT token and returns user ID
-func (uc *VKAuthUseCase) VerifyToken(ctx context.Context, tokenString string) (int, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, domain.ErrInvalidToken
+// Refresh rotates a refresh token and issues a new token pair.
+// Presenting an already rotated token revokes every session of its family.
+func (uc *VKAuthUseCase) Refresh(ctx context.Context, refreshToken, deviceInfo, ipAddress string) (*TokenPair, error) {
+	claims, err := uc.tokenManager.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		if err == jwt.ErrExpiredToken {
+			return nil, domain.ErrSessionExpired
 		}
-		return []byte(uc.jwtSecret), nil
-	})
+		return nil, domain.ErrInvalidToken
+	}
 
-	if err != nil || !token.Valid {
-		return 0, domain.ErrInvalidToken
+	session, err := uc.sessionRepo.GetByToken(ctx, uc.hashToken(refreshToken))
+	if err != nil {
+		if err == domain.ErrSessionNotFound || err == domain.ErrSessionExpired {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return 0, domain.ErrInvalidToken
+	if session.UserID != claims.UserID || session.IsRevoked() {
+		return nil, domain.ErrInvalidToken
 	}
 
-	userID, ok := claims["user_id"].(float64)
-	if !ok {
-		return 0, domain.ErrInvalidToken
+	if session.IsRotated() {
+		return nil, uc.revokeReusedFamily(ctx, session)
 	}
 
-	// Verify session exists
-	hashedToken := uc.hashToken(tokenString)
-	session, err := uc.sessionRepo.GetByToken(ctx, hashedToken)
+	// Reload the user so role changes and bans apply on the next refresh
+	user, err := uc.userRepo.GetByID(ctx, session.UserID)
 	if err != nil {
-		return 0, domain.ErrSessionNotFound
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsBanned {
+		if err := uc.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+			return nil, fmt.Errorf("failed to revoke session family: %w", err)
+		}
+		return nil, domain.ErrUserBanned
+	}
+
+	// Rotation and the new session commit together, so a failed insert
+	// doesn't leave the client with a rotated token and nothing to replace it
+	var tokens *TokenPair
+	reused := false
+	err = uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		// Fails if a concurrent refresh already rotated this token
+		if err := repos.Sessions.MarkRotated(ctx, session.ID); err != nil {
+			if err == domain.ErrSessionNotFound {
+				reused = true
+				return err
+			}
+			return fmt.Errorf("failed to rotate session: %w", err)
+		}
+
+		tokens, err = uc.createSession(ctx, repos.Sessions, user, session.FamilyID, deviceInfo, ipAddress)
+		if err != nil {
+			return fmt.Errorf("failed to create session: %w", err)
+		}
+		return nil
+	})
+	if reused {
+		return nil, uc.revokeReusedFamily(ctx, session)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// revokeReusedFamily revokes all sessions of a family after refresh token reuse
+func (uc *VKAuthUseCase) revokeReusedFamily(ctx context.Context, session *domain.Session) error {
+	uc.log.WarnContext(ctx, "refresh token reuse detected, revoking session family",
+		"user_id", session.UserID, "family_id", session.FamilyID)
+	if err := uc.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke session family: %w", err)
+	}
+	return domain.ErrRefreshTokenReused
+}
+
+// VerifyToken verifies access token and returns its claims (user ID and role)
+func (uc *VKAuthUseCase) VerifyToken(ctx context.Context, tokenString string) (*jwt.Claims, error) {
+	claims, err := uc.tokenManager.ValidateAccessToken(tokenString)
+	if err != nil {
+		if err == jwt.ErrExpiredToken {
+			return nil, domain.ErrTokenExpired
+		}
+		return nil, domain.ErrInvalidToken
+	}
+
+	// Verify session is still alive (not logged out or revoked)
+	session, err := uc.sessionRepo.GetByID(ctx, claims.SessionID)
+	if err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	if session.UserID != claims.UserID {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if session.IsRevoked() {
+		return nil, domain.ErrSessionNotFound
 	}
 
 	if session.IsExpired() {
-		return 0, domain.ErrSessionExpired
+		return nil, domain.ErrSessionExpired
 	}
 
-	return int(userID), nil
+	return claims, nil
 }
 
-// Logout deletes user session
+// Logout revokes the session family of the access token
 func (uc *VKAuthUseCase) Logout(ctx context.Context, tokenString string) error {
-	hashedToken := uc.hashToken(tokenString)
-	return uc.sessionRepo.DeleteByToken(ctx, hashedToken)
+	session, err := uc.sessionFromAccessToken(ctx, tokenString)
+	if err != nil {
+		return err
+	}
+
+	return uc.sessionRepo.RevokeFamily(ctx, session.FamilyID)
 }
 
 // hashToken creates SHA256 hash of token for storage
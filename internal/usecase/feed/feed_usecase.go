@@ -2,29 +2,73 @@ package feed
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math"
 	"sort"
+	"time"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/cache"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/metrics"
 	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/interest"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/photo"
 )
 
+const (
+	// candidatePoolSize is how many candidates are scored per ranking
+	candidatePoolSize = 100
+
+	// deckTTL is how long a ranked deck is served before it is recomputed
+	deckTTL = 15 * time.Minute
+
+	defaultDeckPageSize = 10
+	maxDeckPageSize     = 50
+
+	// relatedInterestWeight is the credit for an interest the other side
+	// doesn't have when they have another one from the same category
+	relatedInterestWeight = 0.5
+)
+
+// DeckTracker keeps the cached deck in sync with swipes and profile changes.
+// Other use cases depend on this interface instead of FeedUseCase.
+type DeckTracker interface {
+	AdvanceDeck(ctx context.Context, userID, swipedUserID int) error
+	InvalidateDeck(ctx context.Context, userID int) error
+}
+
 type FeedUseCase struct {
-	userRepo    repository.UserRepository
-	profileRepo repository.ProfileRepository
-	swipeRepo   repository.SwipeRepository
+	userRepo         repository.UserRepository
+	profileRepo      repository.ProfileRepository
+	swipeRepo        repository.SwipeRepository
+	gallery          photo.Gallery
+	interests        interest.Taxonomy
+	deckCache        cache.Cache
+	excludeSynthetic bool
+	log              *slog.Logger
 }
 
 func NewFeedUseCase(
 	userRepo repository.UserRepository,
 	profileRepo repository.ProfileRepository,
 	swipeRepo repository.SwipeRepository,
+	gallery photo.Gallery,
+	interests interest.Taxonomy,
+	deckCache cache.Cache,
+	excludeSynthetic bool,
+	log *slog.Logger,
 ) *FeedUseCase {
 	return &FeedUseCase{
-		userRepo:    userRepo,
-		profileRepo: profileRepo,
-		swipeRepo:   swipeRepo,
+		userRepo:         userRepo,
+		profileRepo:      profileRepo,
+		swipeRepo:        swipeRepo,
+		gallery:          gallery,
+		interests:        interests,
+		deckCache:        deckCache,
+		excludeSynthetic: excludeSynthetic,
+		log:              log,
 	}
 }
 
@@ -40,89 +84,271 @@ type FeedUserResponse struct {
 	DistanceKm         *float64 `json:"distance_km,omitempty"`
 	CompatibilityScore int      `json:"compatibility_score"`
 	CompatibilityLabel string   `json:"compatibility_label"` // New field
+	SuperLiked         bool     `json:"super_liked"`
+	// Photos are attached when a page is served, so the cached deck never
+	// holds stale URLs
+	Photos []*domain.Photo `json:"photos"`
+
+	ScoreBreakdown *CompatibilityDetails `json:"score_breakdown,omitempty"`
 }
 
-// CompatibilityDetails holds the breakdown of the score
+// CompatibilityDetails holds the breakdown of the score.
+// Component scores are normalized to 0-1, TotalScore is 0-100.
 type CompatibilityDetails struct {
-	TotalScore       float64
-	PersonalityScore float64
-	InterestsScore   float64
-	DistanceScore    float64
-	CommonInterests  []string
+	TotalScore       float64  `json:"total"`
+	PersonalityScore float64  `json:"personality"`
+	InterestsScore   float64  `json:"interests"`
+	DistanceScore    float64  `json:"distance"`
+	CommonInterests  []string `json:"common_interests"`
 }
 
-// GetNextUser returns the next user for feed
-func (uc *FeedUseCase) GetNextUser(ctx context.Context, currentUserID int) (*FeedUserResponse, error) {
-	// Get current user's profile for preferences
-	currentProfile, err := uc.profileRepo.GetByUserID(ctx, currentUserID)
+// FeedPage represents a page of ranked cards from the deck
+type FeedPage struct {
+	Cards     []*FeedUserResponse `json:"cards"`
+	Remaining int                 `json:"remaining"`
+}
+
+// deck is a cached ranking; cards before Cursor were already swiped
+type deck struct {
+	Cards     []*FeedUserResponse `json:"cards"`
+	Cursor    int                 `json:"cursor"`
+	ExpiresAt time.Time           `json:"expires_at"`
+}
+
+// GetFeed returns the next cards of the user's ranked deck.
+// The ranking is cached, swipes advance the deck instead of recomputing it.
+func (uc *FeedUseCase) GetFeed(ctx context.Context, currentUserID, limit int) (*FeedPage, error) {
+	if limit <= 0 {
+		limit = defaultDeckPageSize
+	}
+	if limit > maxDeckPageSize {
+		limit = maxDeckPageSize
+	}
+
+	d, err := uc.loadDeck(ctx, currentUserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current user profile: %w", err)
+		return nil, err
 	}
 
-	// Get current user
-	currentUser, err := uc.userRepo.GetByID(ctx, currentUserID)
+	dropped, err := uc.dropHiddenCards(ctx, currentUserID, d)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current user: %w", err)
+		return nil, err
+	}
+	if dropped && d.Cursor >= len(d.Cards) {
+		// Every card left was hidden, rank again
+		if d, err = uc.loadDeck(ctx, currentUserID); err != nil {
+			return nil, err
+		}
 	}
 
-	// Build filters based on preferences
-	filters := make(map[string]interface{})
+	remaining := d.Cards[d.Cursor:]
+	if len(remaining) > limit {
+		remaining = remaining[:limit]
+	}
+	uc.attachPhotos(ctx, remaining)
 
-	// Filter by onboarding complete
-	// TODO: Re-enable after implementing proper onboarding flow
-	// filters["is_onboarding_complete"] = true
+	return &FeedPage{
+		Cards:     remaining,
+		Remaining: len(d.Cards) - d.Cursor,
+	}, nil
+}
 
-	// Filter by city if set
-	if currentProfile.City != nil && *currentProfile.City != "" {
-		filters["city"] = *currentProfile.City
+// GetNextUser returns the next user for feed
+func (uc *FeedUseCase) GetNextUser(ctx context.Context, currentUserID int) (*FeedUserResponse, error) {
+	page, err := uc.GetFeed(ctx, currentUserID, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// No more users in feed
+	if len(page.Cards) == 0 {
+		return nil, nil
 	}
 
-	// Get candidate profiles (exclude already swiped)
-	candidates, err := uc.profileRepo.SearchProfiles(ctx, filters, 100, 0)
+	return page.Cards[0], nil
+}
+
+// dropHiddenCards removes users banned or shadow-banned after the deck was
+// ranked and saves the deck if any card was removed
+func (uc *FeedUseCase) dropHiddenCards(ctx context.Context, userID int, d *deck) (bool, error) {
+	userIDs := make([]int, 0, len(d.Cards)-d.Cursor)
+	for _, card := range d.Cards[d.Cursor:] {
+		userIDs = append(userIDs, card.UserID)
+	}
+
+	hiddenIDs, err := uc.userRepo.GetHiddenIDs(ctx, userIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search profiles: %w", err)
+		return false, fmt.Errorf("failed to check feed cards: %w", err)
+	}
+	if len(hiddenIDs) == 0 {
+		return false, nil
 	}
 
-	// Filter candidates and calculate scores
-	type ScoredCandidate struct {
-		Profile *domain.Profile
-		Details CompatibilityDetails
-		User    *domain.User
+	hidden := make(map[int]bool, len(hiddenIDs))
+	for _, id := range hiddenIDs {
+		hidden[id] = true
 	}
-	var scoredCandidates []ScoredCandidate
 
-	for _, candidate := range candidates {
-		// Skip self
-		if candidate.UserID == currentUserID {
-			continue
+	cards := append([]*FeedUserResponse{}, d.Cards[:d.Cursor]...)
+	for _, card := range d.Cards[d.Cursor:] {
+		if !hidden[card.UserID] {
+			cards = append(cards, card)
 		}
+	}
+	d.Cards = cards
 
-		// Check if already swiped
-		existingSwipe, err := uc.swipeRepo.GetByUsers(ctx, currentUserID, candidate.UserID)
-		if err == nil && existingSwipe != nil {
-			continue // Already swiped
-		}
+	if err := uc.saveDeck(ctx, userID, d); err != nil {
+		uc.log.WarnContext(ctx, "failed to cache feed deck", "user_id", userID, "error", err)
+	}
+	return true, nil
+}
 
-		// Get candidate user for age
-		candidateUser, err := uc.userRepo.GetByID(ctx, candidate.UserID)
-		if err != nil {
-			continue
-		}
+// attachPhotos fills in photos of the cards, a failure only leaves them empty
+func (uc *FeedUseCase) attachPhotos(ctx context.Context, cards []*FeedUserResponse) {
+	userIDs := make([]int, 0, len(cards))
+	for _, card := range cards {
+		userIDs = append(userIDs, card.UserID)
+	}
 
-		// Check age preferences
-		age := candidateUser.Age()
-		if currentProfile.PrefMinAge != nil && age < *currentProfile.PrefMinAge {
-			continue
+	photos, err := uc.gallery.GetPhotos(ctx, userIDs)
+	if err != nil {
+		uc.log.WarnContext(ctx, "failed to get photos for feed cards", "error", err)
+	}
+
+	for _, card := range cards {
+		card.Photos = photos[card.UserID]
+		if card.Photos == nil {
+			card.Photos = []*domain.Photo{}
 		}
-		if currentProfile.PrefMaxAge != nil && age > *currentProfile.PrefMaxAge {
-			continue
+	}
+}
+
+// AdvanceDeck moves a swiped card behind the cursor so the cached ranking is kept
+func (uc *FeedUseCase) AdvanceDeck(ctx context.Context, userID, swipedUserID int) error {
+	d, err := uc.getCachedDeck(ctx, userID)
+	if err != nil {
+		if err == cache.ErrCacheMiss {
+			return nil
 		}
+		return err
+	}
 
-		// Check gender preferences (if we add gender preference later)
-		// For now, just check opposite gender
-		if currentUser.Gender == candidateUser.Gender {
-			continue // Skip same gender for now
+	for i := d.Cursor; i < len(d.Cards); i++ {
+		if d.Cards[i].UserID == swipedUserID {
+			card := d.Cards[i]
+			copy(d.Cards[d.Cursor+1:i+1], d.Cards[d.Cursor:i])
+			d.Cards[d.Cursor] = card
+			d.Cursor++
+			return uc.saveDeck(ctx, userID, d)
 		}
+	}
+
+	return nil
+}
+
+// InvalidateDeck drops the cached ranking, e.g. after profile or preferences change
+func (uc *FeedUseCase) InvalidateDeck(ctx context.Context, userID int) error {
+	return uc.deckCache.Delete(ctx, deckKey(userID))
+}
+
+// loadDeck returns the cached deck or ranks candidates again when it is missing or used up
+func (uc *FeedUseCase) loadDeck(ctx context.Context, userID int) (*deck, error) {
+	d, err := uc.getCachedDeck(ctx, userID)
+	if err == nil && d.Cursor < len(d.Cards) {
+		return d, nil
+	}
+	if err != nil && err != cache.ErrCacheMiss {
+		uc.log.WarnContext(ctx, "failed to read feed deck", "user_id", userID, "error", err)
+	}
+
+	cards, err := uc.rankCandidates(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	d = &deck{
+		Cards:     cards,
+		ExpiresAt: time.Now().Add(deckTTL),
+	}
+	if err := uc.saveDeck(ctx, userID, d); err != nil {
+		uc.log.WarnContext(ctx, "failed to cache feed deck", "user_id", userID, "error", err)
+	}
+
+	return d, nil
+}
+
+func (uc *FeedUseCase) getCachedDeck(ctx context.Context, userID int) (*deck, error) {
+	data, err := uc.deckCache.Get(ctx, deckKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var d deck
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("failed to decode feed deck: %w", err)
+	}
+	return &d, nil
+}
+
+// saveDeck stores the deck until its original expiry, so advancing doesn't extend the TTL
+func (uc *FeedUseCase) saveDeck(ctx context.Context, userID int, d *deck) error {
+	ttl := time.Until(d.ExpiresAt)
+	if ttl <= 0 {
+		return uc.deckCache.Delete(ctx, deckKey(userID))
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to encode feed deck: %w", err)
+	}
+	return uc.deckCache.Set(ctx, deckKey(userID), data, ttl)
+}
+
+func deckKey(userID int) string {
+	return fmt.Sprintf("feed:deck:%d", userID)
+}
+
+// rankCandidates scores all candidates for the user, best first
+func (uc *FeedUseCase) rankCandidates(ctx context.Context, currentUserID int) ([]*FeedUserResponse, error) {
+	// Get current user's profile for preferences
+	currentProfile, err := uc.profileRepo.GetByUserID(ctx, currentUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user profile: %w", err)
+	}
+
+	// Get current user
+	currentUser, err := uc.userRepo.GetByID(ctx, currentUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	// Get hydrated candidates in one query: swiped users, gender, age window
+	// and distance bounding box are filtered in SQL
+	candidates, err := uc.profileRepo.GetFeedCandidates(ctx, uc.buildCandidateFilter(currentProfile, currentUser))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed candidates: %w", err)
+	}
+	metrics.FeedCandidatePool.Observe(float64(len(candidates)))
+
+	// Calculate scores
+	type ScoredCandidate struct {
+		Profile    *domain.Profile
+		Details    CompatibilityDetails
+		User       *domain.User
+		DistanceKm *float64
+		SuperLiked bool
+	}
+	var scoredCandidates []ScoredCandidate
+
+	// Without the catalog interests are still compared, just without synonyms and categories
+	catalog, err := uc.interests.Catalog(ctx)
+	if err != nil {
+		uc.log.WarnContext(ctx, "failed to load interest catalog", "error", err)
+	}
+
+	for _, c := range candidates {
+		candidate := c.Profile
+		candidateUser := c.User
 
 		// Calculate distance if locations available
 		var distanceKm *float64
@@ -133,7 +359,7 @@ func (uc *FeedUseCase) GetNextUser(ctx context.Context, currentUserID int) (*Fee
 				*candidate.LocationLat, *candidate.LocationLon,
 			)
 
-			// Check distance preference
+			// Bounding box is approximate, check exact distance preference
 			if currentProfile.PrefMaxDistanceKm != nil && distance > float64(*currentProfile.PrefMaxDistanceKm) {
 				continue
 			}
@@ -142,79 +368,118 @@ func (uc *FeedUseCase) GetNextUser(ctx context.Context, currentUserID int) (*Fee
 		}
 
 		// Calculate Compatibility Score
-		details := uc.calculateCompatibilityScore(currentProfile, candidate, distanceKm)
+		details := uc.calculateCompatibilityScore(currentProfile, candidate, distanceKm, catalog)
 		scoredCandidates = append(scoredCandidates, ScoredCandidate{
-			Profile: candidate,
-			Details: details,
-			User:    candidateUser,
+			Profile:    candidate,
+			Details:    details,
+			User:       candidateUser,
+			DistanceKm: distanceKm,
+			SuperLiked: c.SuperLiked,
 		})
 	}
 
-	// Sort by score descending
+	// Users who super-liked me go first, then sort by score descending
 	sort.Slice(scoredCandidates, func(i, j int) bool {
+		if scoredCandidates[i].SuperLiked != scoredCandidates[j].SuperLiked {
+			return scoredCandidates[i].SuperLiked
+		}
 		return scoredCandidates[i].Details.TotalScore > scoredCandidates[j].Details.TotalScore
 	})
 
-	// Return top candidate
-	if len(scoredCandidates) > 0 {
-		best := scoredCandidates[0]
+	cards := make([]*FeedUserResponse, 0, len(scoredCandidates))
+	for _, sc := range scoredCandidates {
+		details := sc.Details
+		cards = append(cards, &FeedUserResponse{
+			ID:                 sc.Profile.ID,
+			UserID:             sc.Profile.UserID,
+			DisplayName:        sc.Profile.DisplayName,
+			Bio:                sc.Profile.Bio,
+			City:               sc.Profile.City,
+			Age:                sc.User.Age(),
+			Interests:          sc.Profile.Interests,
+			DistanceKm:         sc.DistanceKm,
+			CompatibilityScore: int(details.TotalScore),
+			CompatibilityLabel: compatibilityLabel(details, sc.DistanceKm, catalog),
+			ScoreBreakdown:     &details,
+			SuperLiked:         sc.SuperLiked,
+		})
+	}
 
-		// Calculate distance again for response (optimization: could store it)
-		var distanceKm *float64
-		if currentProfile.LocationLat != nil && currentProfile.LocationLon != nil &&
-			best.Profile.LocationLat != nil && best.Profile.LocationLon != nil {
-			d := calculateDistance(
-				*currentProfile.LocationLat, *currentProfile.LocationLon,
-				*best.Profile.LocationLat, *best.Profile.LocationLon,
-			)
-			distanceKm = &d
-		}
+	return cards, nil
+}
 
-		// Generate Compatibility Label
-		label := "Potential Match"
-		details := best.Details
+// compatibilityLabel picks a human readable label from the score breakdown
+func compatibilityLabel(details CompatibilityDetails, distanceKm *float64, catalog *interest.Catalog) string {
+	label := "Potential Match"
+
+	if details.PersonalityScore > 0.8 {
+		label = "✨ Soulmate Potential (90% Match)"
+	} else if len(details.CommonInterests) > 0 {
+		// Pick one random common interest
+		name := catalog.Name(details.CommonInterests[0])
+		label = fmt.Sprintf("🎮 Ideal %s Partner", name)
+	} else if distanceKm != nil && *distanceKm < 5.0 {
+		label = "📍 Neighbor Match (< 5km)"
+	} else if details.TotalScore > 75 {
+		label = "🔥 High Compatibility"
+	}
 
-		// Logic for label
-		if details.PersonalityScore > 0.8 { // Normalized 0-1 (inside calculate it is * 40, so 32/40)
-			// Need to verify range of PersonalityScore from details.
-			// Currently calculateCompatibilityScore returns weighted score component.
-			// Let's adjust calculateCompatibilityScore to return normalized values (0-1) for easier logic.
-		}
+	return label
+}
 
-		// Let's rely on the returned details values (which I will ensure are normalized 0-1)
-
-		if details.PersonalityScore > 0.8 {
-			label = "✨ Soulmate Potential (90% Match)"
-		} else if len(details.CommonInterests) > 0 {
-			// Pick one random common interest
-			interest := details.CommonInterests[0]
-			label = fmt.Sprintf("🎮 Ideal %s Partner", interest)
-		} else if distanceKm != nil && *distanceKm < 5.0 {
-			label = "📍 Neighbor Match (< 5km)"
-		} else if details.TotalScore > 75 {
-			label = "🔥 High Compatibility"
-		}
+// buildCandidateFilter translates the viewer's preferences into SQL filters
+func (uc *FeedUseCase) buildCandidateFilter(me *domain.Profile, user *domain.User) repository.CandidateFilter {
+	filter := repository.CandidateFilter{
+		ViewerID:         user.ID,
+		ExcludeSynthetic: uc.excludeSynthetic,
+		Limit:            candidatePoolSize,
+	}
 
-		return &FeedUserResponse{
-			ID:                 best.Profile.ID,
-			UserID:             best.Profile.UserID,
-			DisplayName:        best.Profile.DisplayName,
-			Bio:                best.Profile.Bio,
-			City:               best.Profile.City,
-			Age:                best.User.Age(),
-			Interests:          best.Profile.Interests,
-			DistanceKm:         distanceKm,
-			CompatibilityScore: int(details.TotalScore),
-			CompatibilityLabel: label,
-		}, nil
+	// Filter by onboarding complete
+	// TODO: Re-enable after implementing proper onboarding flow
+
+	// Filter by city if set
+	if me.City != nil && *me.City != "" {
+		filter.City = me.City
 	}
 
-	// No more users in feed
-	return nil, nil
+	// Both sides have to accept each other: the candidate's gender must fit
+	// my preference and their preference must include my gender
+	filter.Genders = me.InterestedIn.Genders()
+	filter.InterestedIn = domain.InterestedInAccepting(user.Gender)
+
+	// Age window: age >= min means born on or before now-min years,
+	// age <= max means born after now-(max+1) years
+	now := time.Now()
+	if me.PrefMinAge != nil {
+		bornBefore := now.AddDate(-*me.PrefMinAge, 0, 0)
+		filter.BornBefore = &bornBefore
+	}
+	if me.PrefMaxAge != nil {
+		bornAfter := now.AddDate(-(*me.PrefMaxAge + 1), 0, 0)
+		filter.BornAfter = &bornAfter
+	}
+
+	// Distance bounding box (1 degree of latitude is ~111 km)
+	if me.LocationLat != nil && me.LocationLon != nil && me.PrefMaxDistanceKm != nil {
+		const kmPerDegree = 111.045
+		lat, lon := *me.LocationLat, *me.LocationLon
+		dLat := float64(*me.PrefMaxDistanceKm) / kmPerDegree
+		dLon := 180.0
+		if cos := math.Cos(lat * math.Pi / 180.0); cos > 0.01 {
+			dLon = math.Min(dLat/cos, 180.0)
+		}
+		minLat, maxLat := lat-dLat, lat+dLat
+		minLon, maxLon := lon-dLon, lon+dLon
+		filter.MinLat, filter.MaxLat = &minLat, &maxLat
+		filter.MinLon, filter.MaxLon = &minLon, &maxLon
+	}
+
+	return filter
 }
 
 // calculateCompatibilityScore calculates a 0-100 score and returns details
-func (uc *FeedUseCase) calculateCompatibilityScore(me, candidate *domain.Profile, distanceKm *float64) CompatibilityDetails {
+func (uc *FeedUseCase) calculateCompatibilityScore(me, candidate *domain.Profile, distanceKm *float64, catalog *interest.Catalog) CompatibilityDetails {
 	details := CompatibilityDetails{}
 
 	// 1. Personality Compatibility (40%)
@@ -242,26 +507,7 @@ func (uc *FeedUseCase) calculateCompatibilityScore(me, candidate *domain.Profile
 	details.TotalScore += personalityScore * 40
 
 	// 2. Interests Compatibility (30%)
-	interestsScore := 0.0
-	common := 0
-	total := len(me.Interests) + len(candidate.Interests)
-	var commonInterests []string
-
-	if total > 0 {
-		for _, myInt := range me.Interests {
-			for _, theirInt := range candidate.Interests {
-				if myInt == theirInt {
-					common++
-					commonInterests = append(commonInterests, myInt)
-					break
-				}
-			}
-		}
-		union := total - common
-		if union > 0 {
-			interestsScore = float64(common) / float64(union)
-		}
-	}
+	interestsScore, commonInterests := interestSimilarity(me.Interests, candidate.Interests, catalog)
 	details.InterestsScore = interestsScore
 	details.CommonInterests = commonInterests
 	details.TotalScore += interestsScore * 30
@@ -296,23 +542,63 @@ func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	return earthRadius * c
 }
 
-// ResetDislikes deletes all dislikes for a user to refresh the feed
-func (uc *FeedUseCase) ResetDislikes(ctx context.Context, userID int) (int, error) {
-	// Get all user swipes
-	swipes, err := uc.swipeRepo.GetUserSwipes(ctx, userID, 1000, 0)
+// interestSimilarity is the Jaccard similarity of canonical interests where
+// an interest without an exact match still earns relatedInterestWeight if the
+// other side has one from the same category. Returns the shared slugs too.
+func interestSimilarity(mine, theirs []string, catalog *interest.Catalog) (float64, []string) {
+	theirSlugs := make(map[string]bool, len(theirs))
+	theirCategories := make(map[string]bool, len(theirs))
+	for _, raw := range theirs {
+		if slug := catalog.Resolve(raw); slug != "" {
+			theirSlugs[slug] = true
+		}
+		if category := catalog.Category(raw); category != "" {
+			theirCategories[category] = true
+		}
+	}
+
+	mySlugs := make(map[string]bool, len(mine))
+	var common []string
+	shared := 0.0
+	for _, raw := range mine {
+		slug := catalog.Resolve(raw)
+		if slug == "" || mySlugs[slug] {
+			continue
+		}
+		mySlugs[slug] = true
+
+		if theirSlugs[slug] {
+			common = append(common, slug)
+			shared++
+		} else if category := catalog.Category(raw); category != "" && theirCategories[category] {
+			shared += relatedInterestWeight
+		}
+	}
+
+	union := len(mySlugs) + len(theirSlugs) - len(common)
+	if union == 0 {
+		return 0, common
+	}
+	return shared / float64(union), common
+}
+
+// ResetDislikes deletes the user's dislikes so passed profiles come back
+// into the feed. With olderThanDays > 0 only older dislikes are removed.
+func (uc *FeedUseCase) ResetDislikes(ctx context.Context, userID, olderThanDays int) (int, error) {
+	var before *time.Time
+	if olderThanDays > 0 {
+		t := time.Now().AddDate(0, 0, -olderThanDays)
+		before = &t
+	}
+
+	count, err := uc.swipeRepo.DeleteDislikes(ctx, userID, before)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get swipes: %w", err)
-	}
-
-	count := 0
-	// Note: This is a simplified implementation
-	// In production, you'd want a batch delete method in repository
-	for _, swipe := range swipes {
-		if !swipe.IsLike {
-			// Delete dislike swipes
-			// For now, we'll just count them
-			// You'd need to implement a Delete method in swipe repository
-			count++
+		return 0, fmt.Errorf("failed to delete dislikes: %w", err)
+	}
+
+	if count > 0 {
+		if err := uc.InvalidateDeck(ctx, userID); err != nil {
+			uc.log.WarnContext(ctx, "failed to invalidate feed deck", "user_id", userID, "error", err)
 		}
 	}
 
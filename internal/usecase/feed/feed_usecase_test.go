@@ -0,0 +1,70 @@
+package feed
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/cache"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+)
+
+// memoryUsers reports the hidden users, other methods are not used by a cached deck
+type memoryUsers struct {
+	repository.UserRepository
+	hidden map[int]bool
+}
+
+func (r *memoryUsers) GetHiddenIDs(_ context.Context, userIDs []int) ([]int, error) {
+	ids := []int{}
+	for _, id := range userIDs {
+		if r.hidden[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+type nopGallery struct{}
+
+func (nopGallery) GetPhotos(context.Context, []int) (map[int][]*domain.Photo, error) {
+	return map[int][]*domain.Photo{}, nil
+}
+
+func TestGetFeedDropsHiddenCards(t *testing.T) {
+	ctx := context.Background()
+	users := &memoryUsers{hidden: map[int]bool{3: true}}
+	uc := NewFeedUseCase(users, nil, nil, nopGallery{}, nil, cache.NewMemoryCache(), false,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// User 1 was already swiped, user 3 got banned after the deck was ranked
+	cached := &deck{
+		Cards: []*FeedUserResponse{
+			{UserID: 1}, {UserID: 2}, {UserID: 3}, {UserID: 4},
+		},
+		Cursor:    1,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	if err := uc.saveDeck(ctx, 10, cached); err != nil {
+		t.Fatalf("save deck: %v", err)
+	}
+
+	page, err := uc.GetFeed(ctx, 10, 10)
+	if err != nil {
+		t.Fatalf("get feed: %v", err)
+	}
+	if page.Remaining != 2 || len(page.Cards) != 2 || page.Cards[0].UserID != 2 || page.Cards[1].UserID != 4 {
+		t.Fatalf("page = %d cards, remaining %d, want users 2 and 4", len(page.Cards), page.Remaining)
+	}
+
+	saved, err := uc.getCachedDeck(ctx, 10)
+	if err != nil {
+		t.Fatalf("get cached deck: %v", err)
+	}
+	if saved.Cursor != 1 || len(saved.Cards) != 3 || saved.Cards[0].UserID != 1 {
+		t.Fatalf("cached deck = %d cards at cursor %d, want the swiped card kept and the hidden one dropped", len(saved.Cards), saved.Cursor)
+	}
+}
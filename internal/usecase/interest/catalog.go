@@ -0,0 +1,136 @@
+package interest
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+// Catalog resolves free-form interest spellings to catalog entries.
+// A nil Catalog resolves nothing, so callers can fall back to exact matching.
+type Catalog struct {
+	interests []*domain.Interest
+	bySlug    map[string]*domain.Interest
+	byKey     map[string]*domain.Interest
+}
+
+// NewCatalog indexes interests by slug, names and synonyms. When two
+// interests share a spelling the one listed first wins.
+func NewCatalog(interests []*domain.Interest, log *slog.Logger) *Catalog {
+	c := &Catalog{
+		interests: interests,
+		bySlug:    make(map[string]*domain.Interest, len(interests)),
+		byKey:     make(map[string]*domain.Interest, len(interests)*4),
+	}
+
+	for _, interest := range interests {
+		c.bySlug[interest.Slug] = interest
+	}
+	for _, interest := range interests {
+		for _, spelling := range spellings(interest) {
+			key := normalize(spelling)
+			if key == "" {
+				continue
+			}
+			if existing, ok := c.byKey[key]; ok {
+				if existing != interest {
+					log.Warn("interest spelling is already taken",
+						"spelling", spelling, "interest", interest.Slug, "taken_by", existing.Slug)
+				}
+				continue
+			}
+			c.byKey[key] = interest
+		}
+	}
+
+	return c
+}
+
+// Interests returns the catalog in display order
+func (c *Catalog) Interests() []*domain.Interest {
+	if c == nil {
+		return nil
+	}
+	return c.interests
+}
+
+// Lookup finds the interest for a slug, name or synonym in any case
+func (c *Catalog) Lookup(raw string) (*domain.Interest, bool) {
+	if c == nil {
+		return nil, false
+	}
+	if interest, ok := c.bySlug[raw]; ok {
+		return interest, true
+	}
+	interest, ok := c.byKey[normalize(raw)]
+	return interest, ok
+}
+
+// Canonicalize maps submitted interests to slugs, dropping blanks and
+// duplicates. Returns an UnknownInterestError listing what is not in the catalog.
+func (c *Catalog) Canonicalize(raw []string) ([]string, error) {
+	slugs := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	var unknown []string
+
+	for _, value := range raw {
+		if normalize(value) == "" {
+			continue
+		}
+		interest, ok := c.Lookup(value)
+		if !ok {
+			unknown = append(unknown, strings.TrimSpace(value))
+			continue
+		}
+		if seen[interest.Slug] {
+			continue
+		}
+		seen[interest.Slug] = true
+		slugs = append(slugs, interest.Slug)
+	}
+
+	if len(unknown) > 0 {
+		return nil, &domain.UnknownInterestError{Values: unknown}
+	}
+	return slugs, nil
+}
+
+// Resolve returns the slug for a known interest and a normalized spelling
+// otherwise, so interests saved before the catalog still compare sensibly
+func (c *Catalog) Resolve(raw string) string {
+	if interest, ok := c.Lookup(raw); ok {
+		return interest.Slug
+	}
+	return normalize(raw)
+}
+
+// Category returns the category slug of an interest, empty if it is unknown
+func (c *Catalog) Category(raw string) string {
+	if interest, ok := c.Lookup(raw); ok {
+		return interest.Category
+	}
+	return ""
+}
+
+// Name returns the Russian display name of an interest, or raw if it is unknown
+func (c *Catalog) Name(raw string) string {
+	if interest, ok := c.Lookup(raw); ok {
+		return interest.Name
+	}
+	return raw
+}
+
+func spellings(interest *domain.Interest) []string {
+	return append([]string{interest.Slug, interest.Name, interest.NameEn}, interest.Synonyms...)
+}
+
+// normalize lowercases, folds ё into е and treats "_" and "-" as spaces,
+// so "Настольные  игры", "board-games" and "board_games" all compare equal
+// to their catalog spellings
+func normalize(s string) string {
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, "ё", "е")
+	s = strings.NewReplacer("_", " ", "-", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,83 @@
+package interest
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+)
+
+// testCatalog is modeled on the seeded catalog, hedgehogs cover the ё folding
+func testCatalog() *Catalog {
+	return NewCatalog([]*domain.Interest{
+		{Slug: "music", Name: "Музыка", NameEn: "Music", Category: "music", Synonyms: []string{"меломан", "слушать музыку"}},
+		{Slug: "board_games", Name: "Настольные игры", NameEn: "Board games", Category: "games", Synonyms: []string{"настолки"}},
+		{Slug: "hiking", Name: "Походы", NameEn: "Hiking", Category: "outdoors", Synonyms: []string{"хайкинг", "трекинг"}},
+		{Slug: "chess", Name: "Шахматы", NameEn: "Chess", Category: "games"},
+		{Slug: "hedgehogs", Name: "Ежики", NameEn: "Hedgehogs", Category: "pets"},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         []string
+		want        []string
+		wantUnknown []string
+	}{
+		{name: "russian name", raw: []string{"Музыка"}, want: []string{"music"}},
+		{name: "lowercase russian name", raw: []string{"музыка"}, want: []string{"music"}},
+		{name: "slug", raw: []string{"music"}, want: []string{"music"}},
+		{name: "english name in any case", raw: []string{"MUSIC"}, want: []string{"music"}},
+		{name: "synonym", raw: []string{"Меломан"}, want: []string{"music"}},
+		{name: "spellings of one interest collapse", raw: []string{"Музыка", "музыка", "music"}, want: []string{"music"}},
+		{name: "spaces, dashes and underscores", raw: []string{"  настольные   игры ", "board-games", "board_games"}, want: []string{"board_games"}},
+		{name: "ё folds into е", raw: []string{"Ёжики", "ежики"}, want: []string{"hedgehogs"}},
+		{name: "russian synonym in caps", raw: []string{"ТРЕКИНГ", "Походы"}, want: []string{"hiking"}},
+		{name: "order is kept", raw: []string{"шахматы", "Music", "настолки"}, want: []string{"chess", "music", "board_games"}},
+		{name: "blanks are dropped", raw: []string{"", "   ", "music"}, want: []string{"music"}},
+		{name: "unknown interests are listed", raw: []string{"music", " вязание ", "knitting"}, wantUnknown: []string{"вязание", "knitting"}},
+	}
+
+	catalog := testCatalog()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := catalog.Canonicalize(tt.raw)
+
+			if tt.wantUnknown != nil {
+				var unknown *domain.UnknownInterestError
+				if !errors.As(err, &unknown) {
+					t.Fatalf("Canonicalize(%q) error = %v, want UnknownInterestError", tt.raw, err)
+				}
+				if !errors.Is(err, domain.ErrUnknownInterest) {
+					t.Fatalf("error %v doesn't match ErrUnknownInterest", err)
+				}
+				if !reflect.DeepEqual(unknown.Values, tt.wantUnknown) {
+					t.Fatalf("unknown = %q, want %q", unknown.Values, tt.wantUnknown)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Canonicalize(%q): %v", tt.raw, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Canonicalize(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNilCatalogKnowsNothing(t *testing.T) {
+	var catalog *Catalog
+
+	if _, err := catalog.Canonicalize([]string{"music"}); !errors.Is(err, domain.ErrUnknownInterest) {
+		t.Fatalf("Canonicalize on nil catalog error = %v, want ErrUnknownInterest", err)
+	}
+	if got := catalog.Resolve(" Board-Games "); got != "board games" {
+		t.Fatalf("Resolve on nil catalog = %q, want the normalized spelling", got)
+	}
+}
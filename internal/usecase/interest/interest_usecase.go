@@ -0,0 +1,132 @@
+package interest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+)
+
+const (
+	// catalogTTL is how long the catalog is kept in memory before it is reloaded
+	catalogTTL = 5 * time.Minute
+
+	defaultSearchLimit = 20
+)
+
+// Taxonomy gives other use cases the interest catalog
+type Taxonomy interface {
+	Catalog(ctx context.Context) (*Catalog, error)
+}
+
+type InterestUseCase struct {
+	interestRepo repository.InterestRepository
+	log          *slog.Logger
+
+	mu       sync.Mutex
+	catalog  *Catalog
+	loadedAt time.Time
+}
+
+func NewInterestUseCase(interestRepo repository.InterestRepository, log *slog.Logger) *InterestUseCase {
+	return &InterestUseCase{
+		interestRepo: interestRepo,
+		log:          log,
+	}
+}
+
+// Catalog returns the cached catalog, reloading it once it is older than
+// catalogTTL. A stale catalog is served if the reload fails.
+func (uc *InterestUseCase) Catalog(ctx context.Context) (*Catalog, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.catalog != nil && time.Since(uc.loadedAt) < catalogTTL {
+		return uc.catalog, nil
+	}
+
+	interests, err := uc.interestRepo.List(ctx)
+	if err != nil {
+		if uc.catalog != nil {
+			uc.log.WarnContext(ctx, "failed to reload interest catalog", "error", err)
+			return uc.catalog, nil
+		}
+		return nil, fmt.Errorf("failed to load interest catalog: %w", err)
+	}
+
+	uc.catalog = NewCatalog(interests, uc.log)
+	uc.loadedAt = time.Now()
+	return uc.catalog, nil
+}
+
+// Search returns catalog interests for autocomplete. Matches at the start of
+// a name come first, then other prefix matches, then substring matches.
+// An empty query returns the whole catalog.
+func (uc *InterestUseCase) Search(ctx context.Context, query string, limit int) ([]*domain.Interest, error) {
+	catalog, err := uc.Catalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := normalize(query)
+	if q == "" {
+		// Copy so callers can't modify the cached catalog
+		return append([]*domain.Interest{}, catalog.Interests()...), nil
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	type match struct {
+		interest *domain.Interest
+		rank     int
+		index    int
+	}
+	var matches []match
+	for i, interest := range catalog.Interests() {
+		if rank, ok := matchRank(interest, q); ok {
+			matches = append(matches, match{interest: interest, rank: rank, index: i})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].index < matches[j].index
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	result := make([]*domain.Interest, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, m.interest)
+	}
+	return result, nil
+}
+
+// matchRank scores how well q matches the interest, lower is better
+func matchRank(interest *domain.Interest, q string) (int, bool) {
+	if strings.HasPrefix(normalize(interest.Name), q) || strings.HasPrefix(normalize(interest.NameEn), q) {
+		return 0, true
+	}
+
+	best, found := 0, false
+	for _, spelling := range spellings(interest) {
+		key := normalize(spelling)
+		switch {
+		case strings.HasPrefix(key, q):
+			return 1, true
+		case strings.Contains(key, q):
+			best, found = 2, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,249 @@
+package match
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/photo"
+	"github.com/gdugdh24/mpit2026-backend/pkg/crypto"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type MatchUseCase struct {
+	matchRepo   repository.MatchRepository
+	profileRepo repository.ProfileRepository
+	userRepo    repository.UserRepository
+	messageRepo repository.MessageRepository
+	gallery     photo.Gallery
+	encryptor   *crypto.Encryptor
+	log         *slog.Logger
+}
+
+func NewMatchUseCase(
+	matchRepo repository.MatchRepository,
+	profileRepo repository.ProfileRepository,
+	userRepo repository.UserRepository,
+	messageRepo repository.MessageRepository,
+	gallery photo.Gallery,
+	encryptor *crypto.Encryptor,
+	log *slog.Logger,
+) *MatchUseCase {
+	return &MatchUseCase{
+		matchRepo:   matchRepo,
+		profileRepo: profileRepo,
+		userRepo:    userRepo,
+		messageRepo: messageRepo,
+		gallery:     gallery,
+		encryptor:   encryptor,
+		log:         log,
+	}
+}
+
+// MatchUserProfile represents the other user's profile card in a match
+type MatchUserProfile struct {
+	ID           int        `json:"id"`
+	UserID       int        `json:"user_id"`
+	DisplayName  string     `json:"display_name"`
+	Bio          *string    `json:"bio"`
+	City         *string    `json:"city"`
+	Age          int        `json:"age"`
+	Interests    []string   `json:"interests"`
+	IsOnline     bool       `json:"is_online"`
+	LastOnlineAt *time.Time `json:"last_online_at"`
+	// Photos in display order, the primary one is marked
+	Photos []*domain.Photo `json:"photos"`
+}
+
+// LastMessagePreview represents the latest message in a match
+type LastMessagePreview struct {
+	ID        int       `json:"id"`
+	Content   string    `json:"content"`
+	SenderID  int       `json:"sender_id"`
+	IsRead    bool      `json:"is_read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MatchResponse represents a match enriched with the other user's data
+type MatchResponse struct {
+	MatchID     int                 `json:"match_id"`
+	IsActive    bool                `json:"is_active"`
+	User        *MatchUserProfile   `json:"user"`
+	Explanation *string             `json:"match_explanation"`
+	Icebreakers []string            `json:"icebreakers"`
+	AIFallback  bool                `json:"ai_fallback"`
+	MatchedAt   time.Time           `json:"matched_at"`
+	LastMessage *LastMessagePreview `json:"last_message"`
+}
+
+// GetMatches returns active matches of the user with profile cards
+func (uc *MatchUseCase) GetMatches(ctx context.Context, userID int, limit, offset int) ([]*MatchResponse, int, error) {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	total, err := uc.matchRepo.CountActiveMatches(ctx, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count matches: %w", err)
+	}
+
+	matches, err := uc.matchRepo.GetActiveMatches(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	otherUserIDs := make([]int, 0, len(matches))
+	for _, m := range matches {
+		otherUserID, _ := m.GetOtherUserID(userID)
+		otherUserIDs = append(otherUserIDs, otherUserID)
+	}
+	photos := uc.getPhotos(ctx, otherUserIDs)
+
+	// A card that can't be built fails the page, so total always matches
+	// what the client can page through
+	responses := make([]*MatchResponse, 0, len(matches))
+	for _, m := range matches {
+		resp, err := uc.buildMatchResponse(ctx, m, userID, photos)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to build match %d: %w", m.ID, err)
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses, total, nil
+}
+
+// GetMatch returns a single active match if the user is part of it. An ended
+// match, including one ended by a block, is not found, admins see it through
+// the admin API.
+func (uc *MatchUseCase) GetMatch(ctx context.Context, userID, matchID int) (*MatchResponse, error) {
+	m, err := uc.getOwnedMatch(ctx, userID, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.IsActive {
+		return nil, domain.ErrMatchNotFound
+	}
+
+	otherUserID, _ := m.GetOtherUserID(userID)
+	return uc.buildMatchResponse(ctx, m, userID, uc.getPhotos(ctx, []int{otherUserID}))
+}
+
+// Unmatch deactivates the match so neither side can message again
+func (uc *MatchUseCase) Unmatch(ctx context.Context, userID, matchID int) error {
+	m, err := uc.getOwnedMatch(ctx, userID, matchID)
+	if err != nil {
+		return err
+	}
+
+	if !m.IsActive {
+		return domain.ErrMatchNotFound
+	}
+
+	if err := uc.matchRepo.UpdateStatus(ctx, m.ID, false); err != nil {
+		return fmt.Errorf("failed to deactivate match: %w", err)
+	}
+
+	return nil
+}
+
+// getOwnedMatch loads a match and checks that the user belongs to it
+func (uc *MatchUseCase) getOwnedMatch(ctx context.Context, userID, matchID int) (*domain.Match, error) {
+	m, err := uc.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.HasUser(userID) {
+		return nil, domain.ErrForbidden
+	}
+
+	return m, nil
+}
+
+// getPhotos loads photos of all cards of a page at once. Cards are still
+// shown without photos if that fails.
+func (uc *MatchUseCase) getPhotos(ctx context.Context, userIDs []int) map[int][]*domain.Photo {
+	photos, err := uc.gallery.GetPhotos(ctx, userIDs)
+	if err != nil {
+		uc.log.WarnContext(ctx, "failed to get photos for matches", "error", err)
+	}
+	return photos
+}
+
+// buildMatchResponse enriches a match with the other user's card and last message.
+// photos holds the photos of the page's users by user ID.
+func (uc *MatchUseCase) buildMatchResponse(ctx context.Context, m *domain.Match, userID int, photos map[int][]*domain.Photo) (*MatchResponse, error) {
+	otherUserID, _ := m.GetOtherUserID(userID)
+
+	profile, err := uc.profileRepo.GetByUserID(ctx, otherUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, otherUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &MatchResponse{
+		MatchID:  m.ID,
+		IsActive: m.IsActive,
+		User: &MatchUserProfile{
+			ID:           profile.ID,
+			UserID:       profile.UserID,
+			DisplayName:  profile.DisplayName,
+			Bio:          profile.Bio,
+			City:         profile.City,
+			Age:          user.Age(),
+			Interests:    profile.Interests,
+			IsOnline:     user.IsOnline,
+			LastOnlineAt: user.LastOnlineAt,
+			Photos:       photosOf(photos, otherUserID),
+		},
+		Explanation: m.Explanation,
+		Icebreakers: m.Icebreakers,
+		AIFallback:  m.AIFallback,
+		MatchedAt:   m.CreatedAt,
+	}
+
+	messages, err := uc.messageRepo.GetMatchMessagesBefore(ctx, m.ID, userID, 0, 1)
+	if err == nil && len(messages) > 0 {
+		last := messages[0]
+		content, err := uc.encryptor.Decrypt(last.Content)
+		if err != nil {
+			return resp, nil
+		}
+		resp.LastMessage = &LastMessagePreview{
+			ID:        last.ID,
+			Content:   content,
+			SenderID:  last.SenderID,
+			IsRead:    last.IsRead,
+			CreatedAt: last.CreatedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+// photosOf returns the user's photos, never nil so clients always get a list
+func photosOf(photos map[int][]*domain.Photo, userID int) []*domain.Photo {
+	if userPhotos := photos[userID]; userPhotos != nil {
+		return userPhotos
+	}
+	return []*domain.Photo{}
+}
@@ -0,0 +1,330 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/metrics"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/realtime"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/notification"
+	"github.com/gdugdh24/mpit2026-backend/pkg/crypto"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+type MessageUseCase struct {
+	messageRepo repository.MessageRepository
+	matchRepo   repository.MatchRepository
+	userRepo    repository.UserRepository
+	encryptor   *crypto.Encryptor
+	publisher   realtime.Publisher
+	notifier    notification.Notifier
+	log         *slog.Logger
+}
+
+func NewMessageUseCase(
+	messageRepo repository.MessageRepository,
+	matchRepo repository.MatchRepository,
+	userRepo repository.UserRepository,
+	encryptor *crypto.Encryptor,
+	publisher realtime.Publisher,
+	notifier notification.Notifier,
+	log *slog.Logger,
+) *MessageUseCase {
+	return &MessageUseCase{
+		messageRepo: messageRepo,
+		matchRepo:   matchRepo,
+		userRepo:    userRepo,
+		encryptor:   encryptor,
+		publisher:   publisher,
+		notifier:    notifier,
+		log:         log,
+	}
+}
+
+// SendMessageRequest represents a new chat message
+type SendMessageRequest struct {
+	Content string `json:"content" binding:"required,min=1,max=2000"`
+}
+
+// MessagesQuery represents cursor pagination params.
+// Before loads older history, After polls for newer messages.
+type MessagesQuery struct {
+	Before int
+	After  int
+	Limit  int
+}
+
+// MessagesPage represents a page of decrypted messages
+type MessagesPage struct {
+	Messages   []*domain.Message `json:"messages"`
+	NextCursor *int              `json:"next_cursor"`
+	HasMore    bool              `json:"has_more"`
+}
+
+// GetMessages returns a page of decrypted messages for the match.
+// Without After, messages are returned newest first and NextCursor points to
+// older history. With After, messages newer than the cursor are returned
+// oldest first and NextCursor is the last seen message ID.
+// The history of an ended match is not found, like the match itself.
+func (uc *MessageUseCase) GetMessages(ctx context.Context, userID, matchID int, q MessagesQuery) (*MessagesPage, error) {
+	m, err := uc.getOwnedMatch(ctx, userID, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if !m.IsActive {
+		return nil, domain.ErrMatchNotFound
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	// Fetch one extra row to know whether there is another page
+	var messages []*domain.Message
+	if q.After > 0 {
+		messages, err = uc.messageRepo.GetMatchMessagesAfter(ctx, matchID, userID, q.After, limit+1)
+	} else {
+		messages, err = uc.messageRepo.GetMatchMessagesBefore(ctx, matchID, userID, q.Before, limit+1)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	page := &MessagesPage{Messages: []*domain.Message{}}
+	if len(messages) > limit {
+		page.HasMore = true
+		messages = messages[:limit]
+	}
+
+	for _, msg := range messages {
+		if err := uc.decrypt(msg); err != nil {
+			return nil, err
+		}
+		page.Messages = append(page.Messages, msg)
+	}
+
+	if len(messages) > 0 {
+		cursor := messages[len(messages)-1].ID
+		page.NextCursor = &cursor
+	} else if q.After > 0 {
+		cursor := q.After
+		page.NextCursor = &cursor
+	}
+
+	return page, nil
+}
+
+// SendMessage encrypts and stores a message in an active match.
+// Banned and shadow-banned users can't be written to. Messages of a
+// shadow-banned sender are stored but never delivered, the sender isn't told.
+func (uc *MessageUseCase) SendMessage(ctx context.Context, senderID, matchID int, req *SendMessageRequest) (*domain.Message, error) {
+	m, err := uc.getOwnedMatch(ctx, senderID, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.IsActive {
+		return nil, domain.ErrNotMatched
+	}
+
+	recipientID, _ := m.GetOtherUserID(senderID)
+	recipient, err := uc.userRepo.GetByID(ctx, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient: %w", err)
+	}
+	if recipient.IsBanned || recipient.IsShadowBanned {
+		return nil, domain.ErrNotMatched
+	}
+
+	sender, err := uc.userRepo.GetByID(ctx, senderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender: %w", err)
+	}
+	if sender.IsBanned {
+		return nil, domain.ErrUserBanned
+	}
+
+	ciphertext, err := uc.encryptor.Encrypt(req.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
+	}
+
+	msg := &domain.Message{
+		MatchID:  matchID,
+		SenderID: senderID,
+		Content:  ciphertext,
+		IsRead:   false,
+	}
+
+	if err := uc.messageRepo.Create(ctx, msg); err != nil {
+		return nil, fmt.Errorf("failed to create message: %w", err)
+	}
+	metrics.Messages.Inc()
+
+	// Return plaintext to the sender
+	msg.Content = req.Content
+
+	// Push to the recipient and to the sender's other devices
+	event := domain.NewEvent(domain.EventMessageNew, msg)
+	uc.publish(ctx, senderID, event)
+	if sender.IsShadowBanned {
+		return msg, nil
+	}
+	uc.publish(ctx, recipientID, event)
+
+	if err := uc.notifier.Notify(ctx, recipientID, domain.NotificationNewMessage, &notification.NewMessagePayload{
+		MatchID:   matchID,
+		MessageID: msg.ID,
+		SenderID:  senderID,
+	}); err != nil {
+		uc.log.WarnContext(ctx, "failed to notify user about new message", "user_id", recipientID, "error", err)
+	}
+
+	return msg, nil
+}
+
+// TypingPayload is pushed to the other side of a match while the user types
+type TypingPayload struct {
+	MatchID int `json:"match_id"`
+	UserID  int `json:"user_id"`
+}
+
+// SendTyping pushes a typing indicator to the other user of an active match
+func (uc *MessageUseCase) SendTyping(ctx context.Context, userID, matchID int) error {
+	m, err := uc.getOwnedMatch(ctx, userID, matchID)
+	if err != nil {
+		return err
+	}
+
+	if !m.IsActive {
+		return domain.ErrNotMatched
+	}
+
+	otherUserID, _ := m.GetOtherUserID(userID)
+	uc.publish(ctx, otherUserID, domain.NewEvent(domain.EventTyping, &TypingPayload{
+		MatchID: matchID,
+		UserID:  userID,
+	}))
+
+	return nil
+}
+
+// ReadReceipt represents the result of marking messages as read
+type ReadReceipt struct {
+	MatchID   int       `json:"match_id"`
+	MessageID *int      `json:"message_id,omitempty"`
+	ReaderID  int       `json:"reader_id"`
+	Count     int       `json:"count"`
+	ReadAt    time.Time `json:"read_at"`
+}
+
+// MarkAsRead marks a single incoming message as read
+func (uc *MessageUseCase) MarkAsRead(ctx context.Context, userID, matchID, messageID int) (*ReadReceipt, error) {
+	if _, err := uc.getOwnedMatch(ctx, userID, matchID); err != nil {
+		return nil, err
+	}
+
+	msg, err := uc.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.MatchID != matchID {
+		return nil, domain.ErrMessageNotFound
+	}
+
+	// Only the recipient can mark a message as read
+	if msg.SenderID == userID {
+		return nil, domain.ErrUnauthorizedMessage
+	}
+
+	receipt := &ReadReceipt{
+		MatchID:   matchID,
+		MessageID: &msg.ID,
+		ReaderID:  userID,
+		ReadAt:    time.Now(),
+	}
+
+	if msg.IsRead {
+		return receipt, nil
+	}
+
+	if err := uc.messageRepo.MarkAsRead(ctx, messageID); err != nil {
+		return nil, err
+	}
+	receipt.Count = 1
+
+	uc.publish(ctx, msg.SenderID, domain.NewEvent(domain.EventMessageRead, receipt))
+
+	return receipt, nil
+}
+
+// MarkAllAsRead marks all incoming messages in the match as read
+func (uc *MessageUseCase) MarkAllAsRead(ctx context.Context, userID, matchID int) (*ReadReceipt, error) {
+	m, err := uc.getOwnedMatch(ctx, userID, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := uc.messageRepo.MarkMatchAsRead(ctx, matchID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark messages as read: %w", err)
+	}
+
+	receipt := &ReadReceipt{
+		MatchID:  matchID,
+		ReaderID: userID,
+		Count:    count,
+		ReadAt:   time.Now(),
+	}
+
+	if count > 0 {
+		otherUserID, _ := m.GetOtherUserID(userID)
+		uc.publish(ctx, otherUserID, domain.NewEvent(domain.EventMessageRead, receipt))
+	}
+
+	return receipt, nil
+}
+
+// getOwnedMatch loads a match and checks that the user belongs to it
+func (uc *MessageUseCase) getOwnedMatch(ctx context.Context, userID, matchID int) (*domain.Match, error) {
+	m, err := uc.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.HasUser(userID) {
+		return nil, domain.ErrForbidden
+	}
+
+	return m, nil
+}
+
+// publish pushes an event without failing the request on broker errors
+func (uc *MessageUseCase) publish(ctx context.Context, userID int, event *domain.Event) {
+	if err := uc.publisher.Publish(ctx, userID, event); err != nil {
+		uc.log.WarnContext(ctx, "failed to publish event", "event", event.Type, "user_id", userID, "error", err)
+	}
+}
+
+// decrypt replaces stored ciphertext with plaintext in place
+func (uc *MessageUseCase) decrypt(msg *domain.Message) error {
+	plaintext, err := uc.encryptor.Decrypt(msg.Content)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt message %d: %w", msg.ID, err)
+	}
+	msg.Content = plaintext
+	return nil
+}
@@ -0,0 +1,55 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+)
+
+type memoryMatches struct {
+	repository.MatchRepository
+	match *domain.Match
+}
+
+func (r *memoryMatches) GetByID(context.Context, int) (*domain.Match, error) {
+	return r.match, nil
+}
+
+// noMessages fails the test if the history is read at all
+type noMessages struct {
+	repository.MessageRepository
+	t *testing.T
+}
+
+func (r noMessages) GetMatchMessagesBefore(context.Context, int, int, int, int) ([]*domain.Message, error) {
+	r.t.Fatal("history of an ended match was read")
+	return nil, nil
+}
+
+func TestGetMessagesOfEndedMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  int
+		wantErr error
+	}{
+		{name: "participant", userID: 1, wantErr: domain.ErrMatchNotFound},
+		{name: "stranger", userID: 3, wantErr: domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := &memoryMatches{match: &domain.Match{ID: 7, User1ID: 1, User2ID: 2, IsActive: false}}
+			uc := NewMessageUseCase(noMessages{t: t}, matches, nil, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			_, err := uc.GetMessages(context.Background(), tt.userID, 7, MessagesQuery{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetMessages() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/feed"
+)
+
+type ModerationUseCase struct {
+	blockRepo   repository.BlockRepository
+	reportRepo  repository.ReportRepository
+	userRepo    repository.UserRepository
+	matchRepo   repository.MatchRepository
+	messageRepo repository.MessageRepository
+	uow         repository.UnitOfWork
+	deck        feed.DeckTracker
+	log         *slog.Logger
+}
+
+func NewModerationUseCase(
+	blockRepo repository.BlockRepository,
+	reportRepo repository.ReportRepository,
+	userRepo repository.UserRepository,
+	matchRepo repository.MatchRepository,
+	messageRepo repository.MessageRepository,
+	uow repository.UnitOfWork,
+	deck feed.DeckTracker,
+	log *slog.Logger,
+) *ModerationUseCase {
+	return &ModerationUseCase{
+		blockRepo:   blockRepo,
+		reportRepo:  reportRepo,
+		userRepo:    userRepo,
+		matchRepo:   matchRepo,
+		messageRepo: messageRepo,
+		uow:         uow,
+		deck:        deck,
+		log:         log,
+	}
+}
+
+// ReportRequest represents a report about another user
+type ReportRequest struct {
+	Reason  domain.ReportReason `json:"reason" binding:"required,oneof=spam fake harassment underage other"`
+	Details *string             `json:"details" binding:"omitempty,max=1000"`
+	// MessageID is an optional message from the reported user used as evidence
+	MessageID *int `json:"message_id" binding:"omitempty,min=1"`
+}
+
+// BlockUser hides both users from each other and deactivates their match.
+// Both happen in one transaction with the pair locked, so a simultaneous
+// mutual like can't create a match next to the block.
+func (uc *ModerationUseCase) BlockUser(ctx context.Context, blockerID, blockedID int) (*domain.Block, error) {
+	if blockerID == blockedID {
+		return nil, domain.ErrCannotBlockSelf
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, blockedID); err != nil {
+		return nil, err
+	}
+
+	block := &domain.Block{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}
+	err := uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		if err := repos.Swipes.LockPair(ctx, blockerID, blockedID); err != nil {
+			return err
+		}
+
+		if err := repos.Blocks.Create(ctx, block); err != nil {
+			return fmt.Errorf("failed to block user: %w", err)
+		}
+
+		match, err := repos.Matches.GetByUsers(ctx, blockerID, blockedID)
+		if errors.Is(err, domain.ErrMatchNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get match: %w", err)
+		}
+		if !match.IsActive {
+			return nil
+		}
+		if err := repos.Matches.UpdateStatus(ctx, match.ID, false); err != nil {
+			return fmt.Errorf("failed to deactivate match: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Cached decks may still hold the other user's card
+	for _, userID := range []int{blockerID, blockedID} {
+		if err := uc.deck.InvalidateDeck(ctx, userID); err != nil {
+			uc.log.WarnContext(ctx, "failed to invalidate feed deck", "user_id", userID, "error", err)
+		}
+	}
+
+	return block, nil
+}
+
+// ReportUser puts a report into the moderation queue
+func (uc *ModerationUseCase) ReportUser(ctx context.Context, reporterID, reportedID int, req *ReportRequest) (*domain.Report, error) {
+	if reporterID == reportedID {
+		return nil, domain.ErrCannotReportSelf
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, reportedID); err != nil {
+		return nil, err
+	}
+
+	if req.MessageID != nil {
+		if err := uc.checkEvidence(ctx, reporterID, reportedID, *req.MessageID); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &domain.Report{
+		ReporterID: reporterID,
+		ReportedID: reportedID,
+		Reason:     req.Reason,
+		Details:    req.Details,
+		MessageID:  req.MessageID,
+	}
+	if err := uc.reportRepo.Create(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	return report, nil
+}
+
+// checkEvidence makes sure the message was sent by the reported user in a
+// match with the reporter, so reports can't point at other people's chats
+func (uc *ModerationUseCase) checkEvidence(ctx context.Context, reporterID, reportedID, messageID int) error {
+	message, err := uc.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		return err
+	}
+	if message.SenderID != reportedID {
+		return domain.ErrMessageNotFound
+	}
+
+	match, err := uc.matchRepo.GetByID(ctx, message.MatchID)
+	if err != nil {
+		return err
+	}
+	if !match.HasUser(reporterID) || !match.HasUser(reportedID) {
+		return domain.ErrMessageNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package moderation
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository/postgres"
+	"github.com/gdugdh24/mpit2026-backend/internal/testutil/pgtest"
+)
+
+type nopDeck struct{}
+
+func (nopDeck) AdvanceDeck(context.Context, int, int) error { return nil }
+func (nopDeck) InvalidateDeck(context.Context, int) error   { return nil }
+
+func TestBlockUserEndsMatch(t *testing.T) {
+	db := pgtest.Open(t)
+	ctx := context.Background()
+
+	matches := postgres.NewMatchRepository(db)
+	blocks := postgres.NewBlockRepository(db)
+	uc := NewModerationUseCase(
+		blocks,
+		postgres.NewReportRepository(db),
+		postgres.NewUserRepository(db),
+		matches,
+		postgres.NewMessageRepository(db),
+		postgres.NewUnitOfWork(db),
+		nopDeck{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	blocker := pgtest.SeedUser(t, db, string(domain.GenderFemale), "Тест")
+	blocked := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+	match := &domain.Match{User1ID: blocker, User2ID: blocked, IsActive: true}
+	if err := matches.Upsert(ctx, match, blocker); err != nil {
+		t.Fatalf("create match: %v", err)
+	}
+
+	// Blocking twice is fine and keeps one block
+	for i := 0; i < 2; i++ {
+		if _, err := uc.BlockUser(ctx, blocker, blocked); err != nil {
+			t.Fatalf("block %d: %v", i, err)
+		}
+	}
+
+	isBlocked, err := blocks.IsBlocked(ctx, blocked, blocker)
+	if err != nil {
+		t.Fatalf("is blocked: %v", err)
+	}
+	if !isBlocked {
+		t.Fatal("block was not stored")
+	}
+
+	got, err := matches.GetByID(ctx, match.ID)
+	if err != nil {
+		t.Fatalf("get match: %v", err)
+	}
+	if got.IsActive {
+		t.Fatal("match is still active after the block")
+	}
+}
@@ -0,0 +1,178 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/realtime"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Notifier records a notification for a user and pushes it to their live connections.
+// Other use cases depend on this interface instead of NotificationUseCase.
+type Notifier interface {
+	Notify(ctx context.Context, userID int, notificationType domain.NotificationType, payload interface{}) error
+}
+
+type NotificationUseCase struct {
+	notificationRepo repository.NotificationRepository
+	publisher        realtime.Publisher
+	log              *slog.Logger
+}
+
+func NewNotificationUseCase(
+	notificationRepo repository.NotificationRepository,
+	publisher realtime.Publisher,
+	log *slog.Logger,
+) *NotificationUseCase {
+	return &NotificationUseCase{
+		notificationRepo: notificationRepo,
+		publisher:        publisher,
+		log:              log,
+	}
+}
+
+// NewMatchPayload is stored with new_match notifications
+type NewMatchPayload struct {
+	MatchID     int    `json:"match_id"`
+	UserID      int    `json:"user_id"`
+	DisplayName string `json:"display_name"`
+}
+
+// NewLikePayload is stored with new_like notifications
+type NewLikePayload struct {
+	SwipeID     int  `json:"swipe_id"`
+	IsSuperLike bool `json:"is_super_like,omitempty"`
+}
+
+// NewMessagePayload is stored with new_message notifications
+type NewMessagePayload struct {
+	MatchID   int `json:"match_id"`
+	MessageID int `json:"message_id"`
+	SenderID  int `json:"sender_id"`
+}
+
+// IcebreakerReadyPayload is stored when AI icebreakers for a match are ready
+type IcebreakerReadyPayload struct {
+	MatchID int `json:"match_id"`
+}
+
+// ModerationResultPayload is stored for the reporter when their report is closed
+type ModerationResultPayload struct {
+	ReportID int                 `json:"report_id"`
+	Status   domain.ReportStatus `json:"status"`
+	Reason   *string             `json:"reason,omitempty"`
+}
+
+// NotificationEventPayload is pushed over WebSocket with every new notification
+type NotificationEventPayload struct {
+	Notification *domain.Notification `json:"notification"`
+	UnreadCount  int                  `json:"unread_count"`
+}
+
+// NotificationsPage represents a page of notifications with the unread badge
+type NotificationsPage struct {
+	Notifications []*domain.Notification `json:"notifications"`
+	UnreadCount   int                    `json:"unread_count"`
+}
+
+// Notify stores a typed notification and pushes it to the user in real time
+func (uc *NotificationUseCase) Notify(ctx context.Context, userID int, notificationType domain.NotificationType, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification payload: %w", err)
+	}
+
+	notification := &domain.Notification{
+		UserID:  userID,
+		Type:    notificationType,
+		Payload: data,
+		IsRead:  false,
+	}
+
+	if err := uc.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	unread, err := uc.notificationRepo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		uc.log.WarnContext(ctx, "failed to count unread notifications", "user_id", userID, "error", err)
+	}
+
+	event := domain.NewEvent(domain.EventNotificationNew, &NotificationEventPayload{
+		Notification: notification,
+		UnreadCount:  unread,
+	})
+	if err := uc.publisher.Publish(ctx, userID, event); err != nil {
+		uc.log.WarnContext(ctx, "failed to publish notification", "user_id", userID, "error", err)
+	}
+
+	return nil
+}
+
+// GetNotifications returns the user's notifications, newest first
+func (uc *NotificationUseCase) GetNotifications(ctx context.Context, userID int, limit, offset int) (*NotificationsPage, error) {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	notifications, err := uc.notificationRepo.GetUserNotifications(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	if notifications == nil {
+		notifications = []*domain.Notification{}
+	}
+
+	unread, err := uc.notificationRepo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return &NotificationsPage{
+		Notifications: notifications,
+		UnreadCount:   unread,
+	}, nil
+}
+
+// GetUnreadCount returns the unread badge counter
+func (uc *NotificationUseCase) GetUnreadCount(ctx context.Context, userID int) (int, error) {
+	return uc.notificationRepo.GetUnreadCount(ctx, userID)
+}
+
+// MarkAsRead marks a single notification owned by the user as read
+func (uc *NotificationUseCase) MarkAsRead(ctx context.Context, userID, notificationID int) error {
+	notification, err := uc.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		return err
+	}
+
+	if notification.UserID != userID {
+		return domain.ErrForbidden
+	}
+
+	if notification.IsRead {
+		return nil
+	}
+
+	return uc.notificationRepo.MarkAsRead(ctx, notificationID)
+}
+
+// MarkAllAsRead marks all of the user's notifications as read
+func (uc *NotificationUseCase) MarkAllAsRead(ctx context.Context, userID int) error {
+	return uc.notificationRepo.MarkAllAsRead(ctx, userID)
+}
@@ -0,0 +1,224 @@
+package photo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/storage"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/pkg/imaging"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxSide is the longest side of a stored photo, larger uploads are scaled down
+	maxSide = 1600
+	// thumbSide is the longest side of a thumbnail
+	thumbSide = 320
+)
+
+// Gallery resolves photos with URLs for other use cases
+type Gallery interface {
+	GetPhotos(ctx context.Context, userIDs []int) (map[int][]*domain.Photo, error)
+}
+
+// Importer stores photos fetched from other services such as VK
+type Importer interface {
+	HasImported(ctx context.Context, userID int, sourceURL string) (bool, error)
+	Import(ctx context.Context, userID int, data []byte, sourceURL string) (*domain.Photo, error)
+}
+
+type PhotoUseCase struct {
+	photoRepo repository.PhotoRepository
+	storage   storage.Storage
+	log       *slog.Logger
+}
+
+func NewPhotoUseCase(photoRepo repository.PhotoRepository, fileStorage storage.Storage, log *slog.Logger) *PhotoUseCase {
+	return &PhotoUseCase{
+		photoRepo: photoRepo,
+		storage:   fileStorage,
+		log:       log,
+	}
+}
+
+// MovePhotoRequest represents a new position of a photo
+type MovePhotoRequest struct {
+	Position *int `json:"position" binding:"required,min=0"`
+}
+
+// Upload stores a new photo with its thumbnail. The image is re-encoded as
+// JPEG, which strips EXIF metadata including GPS coordinates.
+func (uc *PhotoUseCase) Upload(ctx context.Context, userID int, data []byte) (*domain.Photo, error) {
+	return uc.store(ctx, userID, data, nil)
+}
+
+// HasImported reports whether the photo at sourceURL was already imported
+func (uc *PhotoUseCase) HasImported(ctx context.Context, userID int, sourceURL string) (bool, error) {
+	_, err := uc.photoRepo.GetBySourceURL(ctx, userID, sourceURL)
+	if errors.Is(err, domain.ErrPhotoNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get photo: %w", err)
+	}
+	return true, nil
+}
+
+// Import stores a photo from another service and makes it primary.
+// Returns ErrPhotoAlreadyImported if sourceURL was imported before.
+func (uc *PhotoUseCase) Import(ctx context.Context, userID int, data []byte, sourceURL string) (*domain.Photo, error) {
+	photo, err := uc.store(ctx, userID, data, &sourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if !photo.IsPrimary {
+		if err := uc.photoRepo.SetPrimary(ctx, userID, photo.ID); err != nil {
+			return nil, fmt.Errorf("failed to set primary photo: %w", err)
+		}
+		photo.IsPrimary = true
+	}
+
+	return photo, nil
+}
+
+func (uc *PhotoUseCase) store(ctx context.Context, userID int, data []byte, sourceURL *string) (*domain.Photo, error) {
+	full, thumb, err := imaging.Process(data, maxSide, thumbSide)
+	switch {
+	case errors.Is(err, imaging.ErrUnsupportedFormat):
+		return nil, domain.ErrUnsupportedImage
+	case errors.Is(err, imaging.ErrTooLarge):
+		return nil, domain.ErrImageTooLarge
+	case err != nil:
+		return nil, fmt.Errorf("failed to process image: %w", err)
+	}
+
+	name := uuid.NewString()
+	photo := &domain.Photo{
+		UserID:       userID,
+		StorageKey:   fmt.Sprintf("photos/%d/%s.jpg", userID, name),
+		ThumbnailKey: fmt.Sprintf("photos/%d/%s_thumb.jpg", userID, name),
+		Width:        full.Width,
+		Height:       full.Height,
+		SizeBytes:    len(full.Data),
+		SourceURL:    sourceURL,
+	}
+
+	if err := uc.storage.Put(ctx, photo.StorageKey, full.Data, imaging.TypeJPEG); err != nil {
+		return nil, fmt.Errorf("failed to store photo: %w", err)
+	}
+	if err := uc.storage.Put(ctx, photo.ThumbnailKey, thumb.Data, imaging.TypeJPEG); err != nil {
+		uc.deleteFiles(ctx, photo)
+		return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	if err := uc.photoRepo.Create(ctx, photo); err != nil {
+		uc.deleteFiles(ctx, photo)
+		if errors.Is(err, domain.ErrPhotoLimitReached) || errors.Is(err, domain.ErrPhotoAlreadyImported) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create photo: %w", err)
+	}
+
+	uc.resolveURLs(photo)
+	return photo, nil
+}
+
+// ListPhotos returns the user's photos in display order
+func (uc *PhotoUseCase) ListPhotos(ctx context.Context, userID int) ([]*domain.Photo, error) {
+	photos, err := uc.photoRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+
+	for _, photo := range photos {
+		uc.resolveURLs(photo)
+	}
+	return photos, nil
+}
+
+// GetPhotos returns photos of several users keyed by user ID
+func (uc *PhotoUseCase) GetPhotos(ctx context.Context, userIDs []int) (map[int][]*domain.Photo, error) {
+	photos, err := uc.photoRepo.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+
+	byUser := make(map[int][]*domain.Photo, len(userIDs))
+	for _, photo := range photos {
+		uc.resolveURLs(photo)
+		byUser[photo.UserID] = append(byUser[photo.UserID], photo)
+	}
+	return byUser, nil
+}
+
+// DeletePhoto removes the user's photo and its files
+func (uc *PhotoUseCase) DeletePhoto(ctx context.Context, userID, photoID int) error {
+	photo, err := uc.getOwnPhoto(ctx, userID, photoID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.photoRepo.Delete(ctx, userID, photoID); err != nil {
+		return err
+	}
+
+	uc.deleteFiles(ctx, photo)
+	return nil
+}
+
+// SetPrimary makes the photo the one shown first on cards
+func (uc *PhotoUseCase) SetPrimary(ctx context.Context, userID, photoID int) ([]*domain.Photo, error) {
+	if _, err := uc.getOwnPhoto(ctx, userID, photoID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.photoRepo.SetPrimary(ctx, userID, photoID); err != nil {
+		return nil, err
+	}
+
+	return uc.ListPhotos(ctx, userID)
+}
+
+// MovePhoto changes the photo's position, positions past the end move it last
+func (uc *PhotoUseCase) MovePhoto(ctx context.Context, userID, photoID int, req *MovePhotoRequest) ([]*domain.Photo, error) {
+	if _, err := uc.getOwnPhoto(ctx, userID, photoID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.photoRepo.Move(ctx, userID, photoID, *req.Position); err != nil {
+		return nil, err
+	}
+
+	return uc.ListPhotos(ctx, userID)
+}
+
+// getOwnPhoto hides other users' photos behind ErrPhotoNotFound
+func (uc *PhotoUseCase) getOwnPhoto(ctx context.Context, userID, photoID int) (*domain.Photo, error) {
+	photo, err := uc.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+	if photo.UserID != userID {
+		return nil, domain.ErrPhotoNotFound
+	}
+	return photo, nil
+}
+
+func (uc *PhotoUseCase) resolveURLs(photo *domain.Photo) {
+	photo.URL = uc.storage.URL(photo.StorageKey)
+	photo.ThumbnailURL = uc.storage.URL(photo.ThumbnailKey)
+}
+
+// deleteFiles removes stored files, failures only leave orphaned files behind
+func (uc *PhotoUseCase) deleteFiles(ctx context.Context, photo *domain.Photo) {
+	for _, key := range []string{photo.StorageKey, photo.ThumbnailKey} {
+		if err := uc.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			uc.log.WarnContext(ctx, "failed to delete stored file", "key", key, "error", err)
+		}
+	}
+}
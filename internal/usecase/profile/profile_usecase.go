@@ -3,28 +3,52 @@ package profile
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"math"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/domain"
-	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/gemini"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/llm"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/metrics"
 	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/feed"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/interest"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/photo"
+	"github.com/gdugdh24/mpit2026-backend/pkg/vkapi"
 )
 
 type ProfileUseCase struct {
-	profileRepo  repository.ProfileRepository
-	userRepo     repository.UserRepository
-	geminiClient *gemini.GeminiClient
+	profileRepo repository.ProfileRepository
+	userRepo    repository.UserRepository
+	blockRepo   repository.BlockRepository
+	wingman     llm.AIWingman
+	deck        feed.DeckTracker
+	vkClient    *vkapi.Client
+	photos      photo.Importer
+	interests   interest.Taxonomy
+	log         *slog.Logger
 }
 
 func NewProfileUseCase(
 	profileRepo repository.ProfileRepository,
 	userRepo repository.UserRepository,
-	geminiClient *gemini.GeminiClient,
+	blockRepo repository.BlockRepository,
+	wingman llm.AIWingman,
+	deck feed.DeckTracker,
+	vkClient *vkapi.Client,
+	photos photo.Importer,
+	interests interest.Taxonomy,
+	log *slog.Logger,
 ) *ProfileUseCase {
 	return &ProfileUseCase{
-		profileRepo:  profileRepo,
-		userRepo:     userRepo,
-		geminiClient: geminiClient,
+		profileRepo: profileRepo,
+		userRepo:    userRepo,
+		blockRepo:   blockRepo,
+		wingman:     wingman,
+		deck:        deck,
+		vkClient:    vkClient,
+		photos:      photos,
+		interests:   interests,
+		log:         log,
 	}
 }
 
@@ -37,12 +61,36 @@ type GenerateBioRequest struct {
 	City        string   `json:"city" binding:"required"`
 }
 
-// GenerateBio generates creative bios
-func (uc *ProfileUseCase) GenerateBio(ctx context.Context, req *GenerateBioRequest) (map[string]string, error) {
-	if uc.geminiClient == nil {
-		return nil, fmt.Errorf("gemini client is not initialized")
+// GenerateBioResponse holds bios keyed by style: funny, mysterious and creative
+type GenerateBioResponse struct {
+	Bios map[string]string `json:"bios"`
+	// Fallback means the bios come from templates because the AI failed
+	Fallback bool `json:"fallback"`
+}
+
+// GenerateBio generates creative bios. The user is waiting, so when the AI
+// fails template bios are returned instead of an error.
+func (uc *ProfileUseCase) GenerateBio(ctx context.Context, req *GenerateBioRequest) (*GenerateBioResponse, error) {
+	if uc.wingman == nil {
+		return nil, fmt.Errorf("AI wingman is disabled")
+	}
+
+	// Output of every provider goes through the same checks
+	bios, err := uc.wingman.GenerateBio(ctx, req.DisplayName, req.Interests, req.City)
+	if err == nil {
+		err = llm.ValidateBios(bios)
+	}
+	if err == nil {
+		return &GenerateBioResponse{Bios: llm.KnownBios(bios)}, nil
 	}
-	return uc.geminiClient.GenerateBio(ctx, req.DisplayName, req.Interests, req.City)
+
+	uc.log.WarnContext(ctx, "AI wingman failed, using fallback bios", "error", err)
+	metrics.AIFallbacks.WithLabelValues("bio").Inc()
+	bios, err = llm.NewFakeWingman().GenerateBio(ctx, req.DisplayName, req.Interests, req.City)
+	if err != nil {
+		return nil, err
+	}
+	return &GenerateBioResponse{Bios: bios, Fallback: true}, nil
 }
 
 // ... existing methods ...
@@ -68,19 +116,24 @@ type CreateProfileRequest struct {
 	PrefMinAge        *int     `json:"pref_min_age" binding:"omitempty,min=18,max=100"`
 	PrefMaxAge        *int     `json:"pref_max_age" binding:"omitempty,min=18,max=100"`
 	PrefMaxDistanceKm *int     `json:"pref_max_distance_km" binding:"omitempty,min=1,max=1000"`
+	// InterestedIn defaults to the opposite gender (everyone for non-binary users)
+	InterestedIn *domain.InterestedIn `json:"interested_in" binding:"omitempty,oneof=men women everyone"`
 }
 
 // UpdateProfileRequest represents profile update request
 type UpdateProfileRequest struct {
-	DisplayName       *string   `json:"display_name" binding:"omitempty,min=2,max=100"`
-	Bio               *string   `json:"bio" binding:"omitempty,max=500"`
-	City              *string   `json:"city" binding:"omitempty,max=100"`
-	Interests         *[]string `json:"interests" binding:"omitempty,max=10"`
-	LocationLat       *float64  `json:"location_lat" binding:"omitempty,min=-90,max=90"`
-	LocationLon       *float64  `json:"location_lon" binding:"omitempty,min=-180,max=180"`
-	PrefMinAge        *int      `json:"pref_min_age" binding:"omitempty,min=18,max=100"`
-	PrefMaxAge        *int      `json:"pref_max_age" binding:"omitempty,min=18,max=100"`
-	PrefMaxDistanceKm *int      `json:"pref_max_distance_km" binding:"omitempty,min=1,max=1000"`
+	DisplayName       *string              `json:"display_name" binding:"omitempty,min=2,max=100"`
+	Bio               *string              `json:"bio" binding:"omitempty,max=500"`
+	City              *string              `json:"city" binding:"omitempty,max=100"`
+	Interests         *[]string            `json:"interests" binding:"omitempty,max=10"`
+	LocationLat       *float64             `json:"location_lat" binding:"omitempty,min=-90,max=90"`
+	LocationLon       *float64             `json:"location_lon" binding:"omitempty,min=-180,max=180"`
+	PrefMinAge        *int                 `json:"pref_min_age" binding:"omitempty,min=18,max=100"`
+	PrefMaxAge        *int                 `json:"pref_max_age" binding:"omitempty,min=18,max=100"`
+	PrefMaxDistanceKm *int                 `json:"pref_max_distance_km" binding:"omitempty,min=1,max=1000"`
+	InterestedIn      *domain.InterestedIn `json:"interested_in" binding:"omitempty,oneof=men women everyone"`
+	// Timezone is an IANA name, daily swipe quotas reset at midnight in it
+	Timezone *string `json:"timezone" binding:"omitempty,timezone"`
 }
 
 // ProfileResponse represents profile response with additional info
@@ -101,6 +154,17 @@ func (uc *ProfileUseCase) GetMyProfile(ctx context.Context, userID int) (*domain
 
 // GetProfileByUserID returns profile by user ID with calculated age and distance
 func (uc *ProfileUseCase) GetProfileByUserID(ctx context.Context, targetUserID int, currentUserID *int) (*ProfileResponse, error) {
+	// Blocked users look like they don't exist to each other
+	if currentUserID != nil && *currentUserID != targetUserID {
+		blocked, err := uc.blockRepo.IsBlocked(ctx, *currentUserID, targetUserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check block: %w", err)
+		}
+		if blocked {
+			return nil, domain.ErrProfileNotFound
+		}
+	}
+
 	profile, err := uc.profileRepo.GetByUserID(ctx, targetUserID)
 	if err != nil {
 		return nil, err
@@ -111,6 +175,12 @@ func (uc *ProfileUseCase) GetProfileByUserID(ctx context.Context, targetUserID i
 		return nil, err
 	}
 
+	// Banned and shadow-banned users are hidden from everyone but themselves
+	isSelf := currentUserID != nil && *currentUserID == targetUserID
+	if !isSelf && (user.IsBanned || user.IsShadowBanned) {
+		return nil, domain.ErrProfileNotFound
+	}
+
 	response := &ProfileResponse{
 		Profile: profile,
 		Age:     user.Age(),
@@ -140,15 +210,31 @@ func (uc *ProfileUseCase) CreateProfile(ctx context.Context, userID int, req *Cr
 		return nil, domain.ErrProfileAlreadyExists
 	}
 
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	interestedIn := domain.DefaultInterestedIn(user.Gender)
+	if req.InterestedIn != nil {
+		interestedIn = *req.InterestedIn
+	}
+
+	interests, err := uc.canonicalInterests(ctx, req.Interests)
+	if err != nil {
+		return nil, err
+	}
+
 	profile := &domain.Profile{
 		UserID:               userID,
 		DisplayName:          req.DisplayName,
 		Bio:                  req.Bio,
 		City:                 req.City,
-		Interests:            req.Interests,
+		Interests:            interests,
 		PrefMinAge:           req.PrefMinAge,
 		PrefMaxAge:           req.PrefMaxAge,
 		PrefMaxDistanceKm:    req.PrefMaxDistanceKm,
+		InterestedIn:         interestedIn,
 		IsOnboardingComplete: true,
 	}
 
@@ -166,6 +252,14 @@ func (uc *ProfileUseCase) UpdateProfile(ctx context.Context, userID int, req *Up
 		return nil, err
 	}
 
+	var interests []string
+	if req.Interests != nil {
+		interests, err = uc.canonicalInterests(ctx, *req.Interests)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Update fields if provided
 	if req.DisplayName != nil {
 		profile.DisplayName = *req.DisplayName
@@ -177,7 +271,7 @@ func (uc *ProfileUseCase) UpdateProfile(ctx context.Context, userID int, req *Up
 		profile.City = req.City
 	}
 	if req.Interests != nil {
-		profile.Interests = *req.Interests
+		profile.Interests = interests
 	}
 	if req.LocationLat != nil {
 		profile.LocationLat = req.LocationLat
@@ -194,10 +288,43 @@ func (uc *ProfileUseCase) UpdateProfile(ctx context.Context, userID int, req *Up
 	if req.PrefMaxDistanceKm != nil {
 		profile.PrefMaxDistanceKm = req.PrefMaxDistanceKm
 	}
+	if req.InterestedIn != nil {
+		profile.InterestedIn = *req.InterestedIn
+	}
 
 	if err := uc.profileRepo.Update(ctx, profile); err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
 
+	if req.Timezone != nil {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		user.Timezone = *req.Timezone
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to update timezone: %w", err)
+		}
+	}
+
+	uc.invalidateDeck(ctx, userID)
+
 	return profile, nil
 }
+
+// invalidateDeck drops the cached feed ranking after profile or preferences change
+func (uc *ProfileUseCase) invalidateDeck(ctx context.Context, userID int) {
+	if err := uc.deck.InvalidateDeck(ctx, userID); err != nil {
+		uc.log.WarnContext(ctx, "failed to invalidate feed deck", "user_id", userID, "error", err)
+	}
+}
+
+// canonicalInterests maps submitted interests to catalog slugs.
+// Returns an UnknownInterestError if some are not in the catalog.
+func (uc *ProfileUseCase) canonicalInterests(ctx context.Context, raw []string) ([]string, error) {
+	catalog, err := uc.interests.Catalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return catalog.Canonicalize(raw)
+}
@@ -0,0 +1,66 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/llm"
+)
+
+// failingWingman stands in for a provider whose every call fails with err
+type failingWingman struct {
+	llm.AIWingman
+	err error
+}
+
+func (w failingWingman) GenerateBio(context.Context, string, []string, string) (map[string]string, error) {
+	return nil, w.err
+}
+
+// englishWingman answers without errors, but not in Russian
+type englishWingman struct {
+	llm.AIWingman
+}
+
+func (englishWingman) GenerateBio(context.Context, string, []string, string) (map[string]string, error) {
+	return map[string]string{"funny": "I love movies", "mysterious": "Who knows", "creative": "Let's go"}, nil
+}
+
+func TestGenerateBioFallback(t *testing.T) {
+	req := &GenerateBioRequest{DisplayName: "Анна", Interests: []string{"кино", "походы"}, City: "Казань"}
+	wantFallback, _ := llm.NewFakeWingman().GenerateBio(context.Background(), req.DisplayName, req.Interests, req.City)
+
+	tests := []struct {
+		name         string
+		wingman      llm.AIWingman
+		wantFallback bool
+	}{
+		{name: "provider succeeds", wingman: llm.NewFakeWingman()},
+		{name: "invalid output", wingman: failingWingman{err: fmt.Errorf("%w: failed to parse bios", llm.ErrInvalidOutput)}, wantFallback: true},
+		{name: "failed call", wingman: failingWingman{err: errors.New("provider unavailable")}, wantFallback: true},
+		{name: "output failing validation", wingman: englishWingman{}, wantFallback: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewProfileUseCase(nil, nil, nil, tt.wingman, nopDeck{}, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			resp, err := uc.GenerateBio(context.Background(), req)
+			if err != nil {
+				t.Fatalf("generate bio: %v", err)
+			}
+			if resp.Fallback != tt.wantFallback {
+				t.Fatalf("fallback = %v, want %v", resp.Fallback, tt.wantFallback)
+			}
+			for _, style := range []string{"funny", "mysterious", "creative"} {
+				if resp.Bios[style] != wantFallback[style] {
+					t.Fatalf("%s bio = %q, want %q", style, resp.Bios[style], wantFallback[style])
+				}
+			}
+		})
+	}
+}
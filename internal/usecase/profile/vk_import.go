@@ -0,0 +1,164 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/pkg/vkapi"
+)
+
+// maxAvatarBytes caps the avatar download, VK avatars are well below it
+const maxAvatarBytes = 5 << 20
+
+// VKImporter fills a profile from VK data, the auth use case runs it on signup
+type VKImporter interface {
+	ImportVKInfo(ctx context.Context, user *domain.User, info *vkapi.VKUserInfo) (*VKImportResult, error)
+}
+
+// VKImportResult describes what an import changed
+type VKImportResult struct {
+	Profile        *domain.Profile `json:"profile"`
+	ProfileCreated bool            `json:"profile_created"`
+	CityImported   bool            `json:"city_imported"`
+	// Photo is the imported avatar, nil if there is none or it was imported before
+	Photo *domain.Photo `json:"photo,omitempty"`
+}
+
+// ImportFromVK fetches the user's VK data with their stored VK token and
+// imports it, see ImportVKInfo
+func (uc *ProfileUseCase) ImportFromVK(ctx context.Context, userID int) (*VKImportResult, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.VKAccessToken == nil || *user.VKAccessToken == "" ||
+		(user.VKTokenExpiresAt != nil && time.Now().After(*user.VKTokenExpiresAt)) {
+		return nil, domain.ErrVKTokenExpired
+	}
+
+	info, err := uc.vkClient.GetUserInfo(ctx, *user.VKAccessToken, user.VKID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch VK user info: %w", err)
+	}
+
+	return uc.ImportVKInfo(ctx, user, info)
+}
+
+// ImportVKInfo creates the profile from VK name and city and imports the VK
+// avatar as the primary photo. It only fills in what is missing, so running
+// it again never overwrites the user's own edits or duplicates the avatar.
+func (uc *ProfileUseCase) ImportVKInfo(ctx context.Context, user *domain.User, info *vkapi.VKUserInfo) (*VKImportResult, error) {
+	result := &VKImportResult{}
+
+	profile, err := uc.profileRepo.GetByUserID(ctx, user.ID)
+	switch {
+	case errors.Is(err, domain.ErrProfileNotFound):
+		profile, err = uc.createProfileFromVK(ctx, user, info)
+		if err != nil {
+			return nil, err
+		}
+		result.ProfileCreated = true
+		result.CityImported = profile.City != nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	default:
+		if (profile.City == nil || *profile.City == "") && info.CityTitle() != "" {
+			city := info.CityTitle()
+			profile.City = &city
+			if err := uc.profileRepo.Update(ctx, profile); err != nil {
+				return nil, fmt.Errorf("failed to update profile: %w", err)
+			}
+			result.CityImported = true
+		}
+	}
+	result.Profile = profile
+
+	if result.CityImported {
+		uc.invalidateDeck(ctx, user.ID)
+	}
+
+	photo, err := uc.importVKAvatar(ctx, user.ID, info)
+	if err != nil {
+		return result, err
+	}
+	result.Photo = photo
+
+	return result, nil
+}
+
+func (uc *ProfileUseCase) createProfileFromVK(ctx context.Context, user *domain.User, info *vkapi.VKUserInfo) (*domain.Profile, error) {
+	displayName := info.DisplayName()
+	if displayName == "" {
+		displayName = fmt.Sprintf("id%d", user.VKID)
+	}
+
+	profile := &domain.Profile{
+		UserID:       user.ID,
+		DisplayName:  displayName,
+		InterestedIn: domain.DefaultInterestedIn(user.Gender),
+	}
+	if city := info.CityTitle(); city != "" {
+		profile.City = &city
+	}
+
+	if err := uc.profileRepo.Create(ctx, profile); err != nil {
+		// A concurrent import may have created it first
+		if existing, getErr := uc.profileRepo.GetByUserID(ctx, user.ID); getErr == nil {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// importVKAvatar downloads the avatar unless this exact image was imported
+// before. VK signs avatar URLs, so the query string is ignored when comparing.
+// Only VK CDN URLs are fetched, anything else is skipped.
+func (uc *ProfileUseCase) importVKAvatar(ctx context.Context, userID int, info *vkapi.VKUserInfo) (*domain.Photo, error) {
+	avatarURL := info.AvatarURL()
+	if avatarURL == "" {
+		return nil, nil
+	}
+	if !uc.vkClient.IsCDNURL(avatarURL) {
+		uc.log.WarnContext(ctx, "skipping VK avatar outside the VK CDN", "user_id", userID)
+		return nil, nil
+	}
+
+	sourceURL := avatarURL
+	if parsed, err := url.Parse(avatarURL); err == nil {
+		parsed.RawQuery = ""
+		parsed.Fragment = ""
+		sourceURL = parsed.String()
+	}
+
+	imported, err := uc.photos.HasImported(ctx, userID, sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	if imported {
+		return nil, nil
+	}
+
+	data, err := uc.vkClient.Download(ctx, avatarURL, maxAvatarBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download VK avatar: %w", err)
+	}
+
+	photo, err := uc.photos.Import(ctx, userID, data, sourceURL)
+	switch {
+	case errors.Is(err, domain.ErrPhotoAlreadyImported):
+		return nil, nil
+	case errors.Is(err, domain.ErrPhotoLimitReached):
+		return nil, domain.ErrPhotoLimitReached
+	case err != nil:
+		return nil, fmt.Errorf("failed to import VK avatar: %w", err)
+	}
+
+	return photo, nil
+}
@@ -0,0 +1,201 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/pkg/vkapi"
+)
+
+// memoryProfiles keeps profiles in memory, other methods are not used by the import
+type memoryProfiles struct {
+	repository.ProfileRepository
+	profiles map[int]*domain.Profile
+}
+
+func (r *memoryProfiles) GetByUserID(_ context.Context, userID int) (*domain.Profile, error) {
+	profile, ok := r.profiles[userID]
+	if !ok {
+		return nil, domain.ErrProfileNotFound
+	}
+	copied := *profile
+	return &copied, nil
+}
+
+func (r *memoryProfiles) Create(_ context.Context, profile *domain.Profile) error {
+	if _, ok := r.profiles[profile.UserID]; ok {
+		return fmt.Errorf("profile for user %d already exists", profile.UserID)
+	}
+	copied := *profile
+	r.profiles[profile.UserID] = &copied
+	return nil
+}
+
+func (r *memoryProfiles) Update(_ context.Context, profile *domain.Profile) error {
+	copied := *profile
+	r.profiles[profile.UserID] = &copied
+	return nil
+}
+
+type memoryUsers struct {
+	repository.UserRepository
+	user *domain.User
+}
+
+func (r *memoryUsers) GetByID(context.Context, int) (*domain.User, error) {
+	return r.user, nil
+}
+
+// memoryPhotos remembers imported source URLs like the photos table does
+type memoryPhotos struct {
+	imported map[string]*domain.Photo
+}
+
+func (p *memoryPhotos) HasImported(_ context.Context, _ int, sourceURL string) (bool, error) {
+	_, ok := p.imported[sourceURL]
+	return ok, nil
+}
+
+func (p *memoryPhotos) Import(_ context.Context, userID int, _ []byte, sourceURL string) (*domain.Photo, error) {
+	if _, ok := p.imported[sourceURL]; ok {
+		return nil, domain.ErrPhotoAlreadyImported
+	}
+	photo := &domain.Photo{ID: len(p.imported) + 1, UserID: userID, IsPrimary: len(p.imported) == 0, SourceURL: &sourceURL}
+	p.imported[sourceURL] = photo
+	return photo, nil
+}
+
+type nopDeck struct{}
+
+func (nopDeck) AdvanceDeck(context.Context, int, int) error { return nil }
+func (nopDeck) InvalidateDeck(context.Context, int) error   { return nil }
+
+// fakeVK serves users.get and the avatar. Every users.get returns a freshly
+// signed avatar URL, like VK does.
+type fakeVK struct {
+	server    *httptest.Server
+	avatarURL func(server *httptest.Server, call int) string
+	calls     atomic.Int32
+	downloads atomic.Int32
+}
+
+func newFakeVK(t *testing.T, avatarURL func(server *httptest.Server, call int) string) *fakeVK {
+	t.Helper()
+
+	vk := &fakeVK{avatarURL: avatarURL}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/method/users.get", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "vk-token" {
+			t.Errorf("users.get called with token %q", r.URL.Query().Get("access_token"))
+		}
+		call := int(vk.calls.Add(1))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"response": []map[string]interface{}{{
+				"id":             100,
+				"first_name":     "Анна",
+				"last_name":      "Иванова",
+				"photo_400_orig": vk.avatarURL(vk.server, call),
+				"city":           map[string]interface{}{"id": 1, "title": "Москва"},
+			}},
+		})
+	})
+	mux.HandleFunc("/avatar.jpg", func(w http.ResponseWriter, _ *http.Request) {
+		vk.downloads.Add(1)
+		_, _ = w.Write([]byte("avatar"))
+	})
+	vk.server = httptest.NewServer(mux)
+	t.Cleanup(vk.server.Close)
+	return vk
+}
+
+func newTestProfileUseCase(vk *fakeVK, profiles *memoryProfiles, photos *memoryPhotos) *ProfileUseCase {
+	token := "vk-token"
+	users := &memoryUsers{user: &domain.User{ID: 1, VKID: 100, Gender: domain.GenderFemale, VKAccessToken: &token}}
+
+	return NewProfileUseCase(
+		profiles,
+		users,
+		nil,
+		nil,
+		nopDeck{},
+		vkapi.NewClient(vk.server.URL+"/method"),
+		photos,
+		nil,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+}
+
+func TestImportFromVKRepeatedImport(t *testing.T) {
+	ctx := context.Background()
+	vk := newFakeVK(t, func(server *httptest.Server, call int) string {
+		return fmt.Sprintf("%s/avatar.jpg?sign=%d", server.URL, call)
+	})
+	profiles := &memoryProfiles{profiles: make(map[int]*domain.Profile)}
+	photos := &memoryPhotos{imported: make(map[string]*domain.Photo)}
+	uc := newTestProfileUseCase(vk, profiles, photos)
+
+	first, err := uc.ImportFromVK(ctx, 1)
+	if err != nil {
+		t.Fatalf("first import: %v", err)
+	}
+	if !first.ProfileCreated || !first.CityImported || first.Photo == nil {
+		t.Fatalf("first import = %+v, want created profile with city and photo", first)
+	}
+	if first.Profile.DisplayName != "Анна Иванова" || *first.Profile.City != "Москва" {
+		t.Fatalf("profile = %q from %v, want Анна Иванова from Москва", first.Profile.DisplayName, *first.Profile.City)
+	}
+
+	// The user moves to another city in the app
+	edited := "Казань"
+	profiles.profiles[1].City = &edited
+
+	second, err := uc.ImportFromVK(ctx, 1)
+	if err != nil {
+		t.Fatalf("second import: %v", err)
+	}
+	if second.ProfileCreated || second.CityImported || second.Photo != nil {
+		t.Fatalf("second import = %+v, want nothing changed", second)
+	}
+	if city := profiles.profiles[1].City; city == nil || *city != edited {
+		t.Fatalf("city after second import = %v, want %s", city, edited)
+	}
+	if len(photos.imported) != 1 {
+		t.Fatalf("got %d imported photos, want 1", len(photos.imported))
+	}
+	if got := vk.downloads.Load(); got != 1 {
+		t.Fatalf("avatar downloaded %d times, want 1", got)
+	}
+	if got := vk.calls.Load(); got != 2 {
+		t.Fatalf("users.get called %d times, want 2", got)
+	}
+}
+
+func TestImportFromVKSkipsAvatarOutsideVKCDN(t *testing.T) {
+	ctx := context.Background()
+	vk := newFakeVK(t, func(*httptest.Server, int) string {
+		return "https://evil.example/avatar.jpg"
+	})
+	profiles := &memoryProfiles{profiles: make(map[int]*domain.Profile)}
+	photos := &memoryPhotos{imported: make(map[string]*domain.Photo)}
+	uc := newTestProfileUseCase(vk, profiles, photos)
+
+	result, err := uc.ImportFromVK(ctx, 1)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if !result.ProfileCreated || result.Photo != nil {
+		t.Fatalf("import = %+v, want created profile without photo", result)
+	}
+	if len(photos.imported) != 0 || vk.downloads.Load() != 0 {
+		t.Fatal("avatar outside the VK CDN was imported")
+	}
+}
@@ -0,0 +1,63 @@
+package swipe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/jobs"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+)
+
+// Background job types of the swipe use case
+const (
+	JobLearnPreferences = "swipe.learn_preferences"
+	JobEnrichMatch      = "match.enrich_ai"
+)
+
+var errWingmanDisabled = errors.New("AI wingman is disabled")
+
+type learnPreferencesPayload struct {
+	SwipeID  int `json:"swipe_id"`
+	SwiperID int `json:"swiper_id"`
+	SwipedID int `json:"swiped_id"`
+}
+
+type enrichMatchPayload struct {
+	MatchID int `json:"match_id"`
+	User1ID int `json:"user1_id"`
+	User2ID int `json:"user2_id"`
+}
+
+// enqueue adds a background job through the given repository, inside a
+// unit of work it becomes visible to workers only when the transaction commits
+func enqueue(ctx context.Context, jobRepo repository.JobRepository, jobType string, payload interface{}) error {
+	job, err := domain.NewJob(jobType, payload)
+	if err != nil {
+		return err
+	}
+	return jobRepo.Enqueue(ctx, job)
+}
+
+// LearnPreferencesJob shifts the swiper's preference vector after a like
+func (uc *SwipeUseCase) LearnPreferencesJob(ctx context.Context, job *domain.Job) error {
+	var payload learnPreferencesPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return jobs.Permanent(err)
+	}
+	return uc.updateUserPreferences(ctx, payload.SwipeID, payload.SwiperID, payload.SwipedID)
+}
+
+// EnrichMatchJob generates the AI explanation and icebreakers of a new match
+func (uc *SwipeUseCase) EnrichMatchJob(ctx context.Context, job *domain.Job) error {
+	var payload enrichMatchPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return jobs.Permanent(err)
+	}
+	if uc.wingman == nil {
+		return jobs.Permanent(errWingmanDisabled)
+	}
+	finalAttempt := job.Attempts >= job.MaxAttempts
+	return uc.enrichMatchWithAI(ctx, payload.MatchID, payload.User1ID, payload.User2ID, finalAttempt)
+}
@@ -0,0 +1,137 @@
+package swipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+)
+
+// DailyLimits caps likes and super-likes per day, 0 means unlimited
+type DailyLimits struct {
+	Likes      int
+	SuperLikes int
+}
+
+func (l DailyLimits) forDirection(direction domain.SwipeDirection) int {
+	switch direction {
+	case domain.SwipeRight:
+		return l.Likes
+	case domain.SwipeSuper:
+		return l.SuperLikes
+	default:
+		return 0
+	}
+}
+
+// QuotaStatus is the usage of one daily limit. Remaining is null when unlimited
+type QuotaStatus struct {
+	Limit     int  `json:"limit"`
+	Used      int  `json:"used"`
+	Remaining *int `json:"remaining"`
+}
+
+// QuotaResponse represents the user's daily swipe quotas
+type QuotaResponse struct {
+	Likes      QuotaStatus `json:"likes"`
+	SuperLikes QuotaStatus `json:"super_likes"`
+	Timezone   string      `json:"timezone"`
+	ResetsAt   time.Time   `json:"resets_at"`
+}
+
+// GetQuota returns the like and super-like usage of the current day. A day
+// ends at midnight in the timezone the user had when it started.
+func (uc *SwipeUseCase) GetQuota(ctx context.Context, userID int) (*QuotaResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	loc := user.Location()
+	resetsAt := nextMidnight(now, loc)
+
+	likes, likesReset, err := uc.quotaStatus(ctx, userID, domain.SwipeRight, now)
+	if err != nil {
+		return nil, err
+	}
+	superLikes, superLikesReset, err := uc.quotaStatus(ctx, userID, domain.SwipeSuper, now)
+	if err != nil {
+		return nil, err
+	}
+	// The soonest reset of a day already started wins
+	for _, reset := range []*time.Time{likesReset, superLikesReset} {
+		if reset != nil && reset.Before(resetsAt) {
+			resetsAt = *reset
+		}
+	}
+
+	return &QuotaResponse{
+		Likes:      likes,
+		SuperLikes: superLikes,
+		Timezone:   loc.String(),
+		ResetsAt:   resetsAt,
+	}, nil
+}
+
+// quotaStatus returns the usage of a direction and when its day ends, nil if
+// no swipe was counted today
+func (uc *SwipeUseCase) quotaStatus(ctx context.Context, userID int, direction domain.SwipeDirection, now time.Time) (QuotaStatus, *time.Time, error) {
+	quota, err := uc.quotaRepo.Get(ctx, userID, direction, now)
+	if err != nil {
+		return QuotaStatus{}, nil, fmt.Errorf("failed to get quota: %w", err)
+	}
+
+	status := QuotaStatus{Limit: uc.limits.forDirection(direction)}
+	var resetsAt *time.Time
+	if quota != nil {
+		status.Used = quota.Used
+		resetsAt = &quota.ResetsAt
+	}
+	if status.Limit > 0 {
+		remaining := status.Limit - status.Used
+		if remaining < 0 {
+			remaining = 0
+		}
+		status.Remaining = &remaining
+	}
+	return status, resetsAt, nil
+}
+
+// consumeQuota counts a like or super-like of user against their daily limit.
+// It runs in the swipe transaction, so a rejected or failed swipe isn't
+// counted, and the counter row lock orders concurrent swipes of the user.
+// Returns a *domain.QuotaExceededError if nothing is left today.
+func (uc *SwipeUseCase) consumeQuota(ctx context.Context, quotas repository.QuotaRepository, user *domain.User, direction domain.SwipeDirection) error {
+	if !direction.IsLike() {
+		return nil
+	}
+
+	limit := uc.limits.forDirection(direction)
+	now := time.Now()
+	quota, err := quotas.Consume(ctx, user.ID, direction, limit, now, nextMidnight(now, user.Location()))
+	if errors.Is(err, domain.ErrQuotaExceeded) {
+		quotaErr := &domain.QuotaExceededError{
+			Direction: direction,
+			Limit:     limit,
+			ResetsAt:  nextMidnight(now, user.Location()),
+		}
+		if quota != nil {
+			quotaErr.ResetsAt = quota.ResetsAt
+		}
+		return quotaErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to count swipe: %w", err)
+	}
+	return nil
+}
+
+// nextMidnight returns the start of the next day in loc
+func nextMidnight(now time.Time, loc *time.Location) time.Time {
+	local := now.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+}
@@ -2,41 +2,96 @@ package swipe
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/gdugdh24/mpit2026-backend/internal/domain"
-	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/gemini"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/llm"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/metrics"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/realtime"
 	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/feed"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/notification"
+	"github.com/gdugdh24/mpit2026-backend/internal/usecase/photo"
 )
 
 type SwipeUseCase struct {
-	swipeRepo    repository.SwipeRepository
-	matchRepo    repository.MatchRepository
-	profileRepo  repository.ProfileRepository
-	userRepo     repository.UserRepository
-	geminiClient *gemini.GeminiClient
+	swipeRepo       repository.SwipeRepository
+	matchRepo       repository.MatchRepository
+	uow             repository.UnitOfWork
+	profileRepo     repository.ProfileRepository
+	userRepo        repository.UserRepository
+	blockRepo       repository.BlockRepository
+	quotaRepo       repository.QuotaRepository
+	wingman         llm.AIWingman
+	fallbackWingman llm.AIWingman
+	publisher       realtime.Publisher
+	notifier        notification.Notifier
+	deck            feed.DeckTracker
+	gallery         photo.Gallery
+	undoWindow      time.Duration
+	limits          DailyLimits
+	log             *slog.Logger
 }
 
 func NewSwipeUseCase(
 	swipeRepo repository.SwipeRepository,
 	matchRepo repository.MatchRepository,
+	uow repository.UnitOfWork,
 	profileRepo repository.ProfileRepository,
 	userRepo repository.UserRepository,
-	geminiClient *gemini.GeminiClient,
+	blockRepo repository.BlockRepository,
+	quotaRepo repository.QuotaRepository,
+	wingman llm.AIWingman,
+	publisher realtime.Publisher,
+	notifier notification.Notifier,
+	deck feed.DeckTracker,
+	gallery photo.Gallery,
+	undoWindow time.Duration,
+	limits DailyLimits,
+	log *slog.Logger,
 ) *SwipeUseCase {
 	return &SwipeUseCase{
-		swipeRepo:    swipeRepo,
-		matchRepo:    matchRepo,
-		profileRepo:  profileRepo,
-		userRepo:     userRepo,
-		geminiClient: geminiClient,
+		swipeRepo:       swipeRepo,
+		matchRepo:       matchRepo,
+		uow:             uow,
+		profileRepo:     profileRepo,
+		userRepo:        userRepo,
+		blockRepo:       blockRepo,
+		quotaRepo:       quotaRepo,
+		wingman:         wingman,
+		fallbackWingman: llm.NewFakeWingman(),
+		publisher:       publisher,
+		notifier:        notifier,
+		deck:            deck,
+		gallery:         gallery,
+		undoWindow:      undoWindow,
+		limits:          limits,
+		log:             log,
 	}
 }
 
-// SwipeRequest represents a swipe action
+// SwipeRequest represents a swipe action.
+// Direction takes precedence, is_like is kept for older clients.
 type SwipeRequest struct {
-	SwipedUserID int  `json:"swiped_user_id" binding:"required"`
-	IsLike       bool `json:"is_like"`
+	SwipedUserID int                   `json:"swiped_user_id" binding:"required"`
+	IsLike       bool                  `json:"is_like"`
+	Direction    domain.SwipeDirection `json:"direction" binding:"omitempty,oneof=left right super"`
+}
+
+// SwipeDirection resolves the requested direction
+func (r *SwipeRequest) SwipeDirection() domain.SwipeDirection {
+	if r.Direction != "" {
+		return r.Direction
+	}
+	if r.IsLike {
+		return domain.SwipeRight
+	}
+	return domain.SwipeLeft
 }
 
 // SwipeResponse represents swipe result
@@ -55,113 +110,316 @@ type MatchedUserProfile struct {
 	City        *string  `json:"city"`
 	Age         int      `json:"age"`
 	DistanceKm  *float64 `json:"distance_km"`
+	// Photos in display order, the primary one is marked
+	Photos []*domain.Photo `json:"photos"`
+}
+
+// MatchEventPayload is pushed to both users when a match is created
+type MatchEventPayload struct {
+	Match *domain.Match       `json:"match"`
+	User  *MatchedUserProfile `json:"user"`
+}
+
+// MatchAIReadyPayload is pushed to both users when the AI content of a match is ready
+type MatchAIReadyPayload struct {
+	MatchID     int      `json:"match_id"`
+	Explanation string   `json:"explanation"`
+	Icebreakers []string `json:"icebreakers"`
+	// Fallback means the texts come from templates because the AI failed
+	Fallback bool `json:"fallback"`
+}
+
+// LikeEventPayload is pushed to a user who received a like
+type LikeEventPayload struct {
+	SwipeID     int    `json:"swipe_id"`
+	IsSuperLike bool   `json:"is_super_like"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// UndoSwipeResponse represents the result of undoing the last swipe
+type UndoSwipeResponse struct {
+	Swipe            *domain.Swipe `json:"swipe"`
+	MatchDeactivated bool          `json:"match_deactivated"`
 }
 
 // LikeReceivedResponse represents a like received
 type LikeReceivedResponse struct {
-	SwipeID   int                 `json:"swipe_id"`
-	User      *MatchedUserProfile `json:"user"`
-	CreatedAt string              `json:"created_at"`
+	SwipeID     int                 `json:"swipe_id"`
+	IsSuperLike bool                `json:"is_super_like"`
+	User        *MatchedUserProfile `json:"user"`
+	CreatedAt   string              `json:"created_at"`
 }
 
-// CreateSwipe creates a new swipe and checks for match
+// CreateSwipe creates a new swipe and checks for match.
+// All checks, the swipe insert, mutual like check and match insert run in one
+// transaction with the user pair locked, so simultaneous mutual likes create
+// exactly one match. The like that commits first can't see the other one yet,
+// so it takes the pair lock again after committing: that waits for the other
+// like in flight, and both responses report is_match.
+// Banned and shadow-banned users can't be swiped on. A shadow-banned swiper's
+// swipes are stored, but their likes never reach anyone or create matches.
 func (uc *SwipeUseCase) CreateSwipe(ctx context.Context, swiperID int, req *SwipeRequest) (*SwipeResponse, error) {
 	// Validate: can't swipe yourself
 	if swiperID == req.SwipedUserID {
 		return nil, domain.ErrCannotSwipeSelf
 	}
 
-	// Check if already swiped
-	existingSwipe, err := uc.swipeRepo.GetByUsers(ctx, swiperID, req.SwipedUserID)
-	if err == nil && existingSwipe != nil {
-		return nil, domain.ErrSwipeAlreadyExists
-	}
+	direction := req.SwipeDirection()
 
-	// Create swipe
 	swipe := &domain.Swipe{
-		SwiperID: swiperID,
-		SwipedID: req.SwipedUserID,
-		IsLike:   req.IsLike,
+		SwiperID:  swiperID,
+		SwipedID:  req.SwipedUserID,
+		IsLike:    direction.IsLike(),
+		Direction: direction,
 	}
 
-	if err := uc.swipeRepo.Create(ctx, swipe); err != nil {
+	var (
+		swiper *domain.User
+		match  *domain.Match
+	)
+	err := uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		// The lock comes first, so a like of the other user that is checking
+		// the pair already holds it or waits for it
+		if err := repos.Swipes.LockPair(ctx, swiperID, req.SwipedUserID); err != nil {
+			return err
+		}
+
+		var err error
+		swiper, err = uc.checkSwipeAllowed(ctx, repos, swiperID, req.SwipedUserID)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Swipes.Create(ctx, swipe); err != nil {
+			return err
+		}
+
+		if err := uc.consumeQuota(ctx, repos.Quotas, swiper, direction); err != nil {
+			return err
+		}
+
+		if !swipe.IsLike || swiper.IsShadowBanned {
+			return nil
+		}
+
+		// Background jobs are enqueued in the transaction, so they exist
+		// exactly when the swipe does
+		if err := enqueue(ctx, repos.Jobs, JobLearnPreferences, &learnPreferencesPayload{
+			SwipeID:  swipe.ID,
+			SwiperID: swiperID,
+			SwipedID: req.SwipedUserID,
+		}); err != nil {
+			return err
+		}
+
+		isMutual, err := repos.Swipes.CheckMutualLike(ctx, swiperID, req.SwipedUserID)
+		if err != nil || !isMutual {
+			return err
+		}
+
+		match, err = createMatch(ctx, repos.Matches, swiperID, req.SwipedUserID)
+		if err != nil {
+			return err
+		}
+		// The pair matched before and one of them ended it
+		if !match.IsActive {
+			match = nil
+			return nil
+		}
+		if uc.wingman == nil {
+			return nil
+		}
+
+		return enqueue(ctx, repos.Jobs, JobEnrichMatch, &enrichMatchPayload{
+			MatchID: match.ID,
+			User1ID: swiperID,
+			User2ID: req.SwipedUserID,
+		})
+	})
+	if err != nil {
+		for _, known := range []error{domain.ErrSwipeAlreadyExists, domain.ErrUserNotFound, domain.ErrUserBanned} {
+			if errors.Is(err, known) {
+				return nil, known
+			}
+		}
+		var quotaErr *domain.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			return nil, quotaErr
+		}
 		return nil, fmt.Errorf("failed to create swipe: %w", err)
 	}
 
+	metrics.Swipes.WithLabelValues(string(direction)).Inc()
+	if swipe.IsLike {
+		metrics.Likes.Inc()
+	}
+	if match != nil {
+		metrics.Matches.Inc()
+	}
+
+	// Move the card out of the cached feed deck without reranking
+	if err := uc.deck.AdvanceDeck(ctx, swiperID, req.SwipedUserID); err != nil {
+		uc.log.WarnContext(ctx, "failed to advance feed deck", "user_id", swiperID, "error", err)
+	}
+
 	response := &SwipeResponse{
-		IsMatch: false,
+		IsMatch: match != nil,
 		Swipe:   swipe,
+		Match:   match,
 	}
 
-	// If it's a like, check for mutual like (match)
-	if req.IsLike {
-		// 1. Reinforcement Learning: Update user's preferences
-		// We do this asynchronously to not block the response
-		go uc.updateUserPreferences(ctx, swiperID, req.SwipedUserID)
+	if !swipe.IsLike || swiper.IsShadowBanned {
+		return response, nil
+	}
 
-		isMutual, err := uc.swipeRepo.CheckMutualLike(ctx, swiperID, req.SwipedUserID)
-		if err != nil {
-			fmt.Printf("❌ [Match] CheckMutualLike failed: %v\n", err)
-			return response, nil // Return swipe even if match check fails
+	// A super-like jumps to the top of the recipient's deck
+	if swipe.IsSuperLike() {
+		if err := uc.deck.InvalidateDeck(ctx, req.SwipedUserID); err != nil {
+			uc.log.WarnContext(ctx, "failed to invalidate feed deck", "user_id", req.SwipedUserID, "error", err)
 		}
+	}
 
-		fmt.Printf("🔍 [Match] CheckMutualLike result: %v (swiper=%d, swiped=%d)\n", isMutual, swiperID, req.SwipedUserID)
-
-		if isMutual {
-			fmt.Printf("💕 [Match] Mutual like detected! Creating match...\n")
-			// Create match
-			match, err := uc.createMatch(ctx, swiperID, req.SwipedUserID)
-			if err != nil {
-				fmt.Printf("❌ [Match] createMatch failed: %v\n", err)
-				return response, nil // Return swipe even if match creation fails
-			}
-			fmt.Printf("✅ [Match] Match created: ID=%d\n", match.ID)
-
-			// Get matched user profile
-			matchedUser, err := uc.getMatchedUserProfile(ctx, req.SwipedUserID)
-			if err == nil {
-				fmt.Printf("✅ [Match] Got matched user profile: %s\n", matchedUser.DisplayName)
-				response.IsMatch = true
-				response.Match = match
+	if match == nil {
+		// A simultaneous like of the other user may have matched after this
+		// transaction committed. Its events reach both users, only the
+		// response is filled in here.
+		existing, err := uc.matchAfterPairUnlocked(ctx, swiperID, req.SwipedUserID)
+		if err != nil {
+			uc.log.WarnContext(ctx, "failed to recheck match", "user_id", swiperID, "error", err)
+		}
+		if existing != nil {
+			response.IsMatch = true
+			response.Match = existing
+			if matchedUser, err := uc.getMatchedUserProfile(ctx, req.SwipedUserID); err == nil {
 				response.MatchedUser = matchedUser
-
-				// 2. AI Wingman: Generate explanation and icebreakers
-				// Call synchronously for debugging (normally would be async)
-				if uc.geminiClient != nil {
-					fmt.Printf("✅ [AI Wingman] geminiClient is available, calling enrichMatchWithAI...\n")
-					uc.enrichMatchWithAI(ctx, match.ID, swiperID, req.SwipedUserID)
-				} else {
-					fmt.Printf("❌ [AI Wingman] geminiClient is nil, skipping AI enrichment\n")
-				}
-			} else {
-				fmt.Printf("❌ [Match] getMatchedUserProfile failed: %v\n", err)
 			}
+			return response, nil
 		}
+
+		// Let the recipient know someone liked them
+		uc.publish(ctx, req.SwipedUserID, domain.NewEvent(domain.EventLikeNew, &LikeEventPayload{
+			SwipeID:     swipe.ID,
+			IsSuperLike: swipe.IsSuperLike(),
+			CreatedAt:   swipe.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}))
+		uc.notify(ctx, req.SwipedUserID, domain.NotificationNewLike, &notification.NewLikePayload{
+			SwipeID:     swipe.ID,
+			IsSuperLike: swipe.IsSuperLike(),
+		})
+		return response, nil
+	}
+
+	uc.log.InfoContext(ctx, "match created", "match_id", match.ID, "user_id", swiperID, "matched_user_id", req.SwipedUserID)
+
+	// Push the match to both users
+	matchedUser, err := uc.getMatchedUserProfile(ctx, req.SwipedUserID)
+	if err != nil {
+		uc.log.WarnContext(ctx, "failed to get matched user profile", "user_id", req.SwipedUserID, "error", err)
+	} else {
+		response.MatchedUser = matchedUser
+		uc.publish(ctx, swiperID, domain.NewEvent(domain.EventMatchNew, &MatchEventPayload{
+			Match: match,
+			User:  matchedUser,
+		}))
+		uc.notify(ctx, swiperID, domain.NotificationNewMatch, &notification.NewMatchPayload{
+			MatchID:     match.ID,
+			UserID:      req.SwipedUserID,
+			DisplayName: matchedUser.DisplayName,
+		})
+	}
+	if swiperProfile, err := uc.getMatchedUserProfile(ctx, swiperID); err == nil {
+		uc.publish(ctx, req.SwipedUserID, domain.NewEvent(domain.EventMatchNew, &MatchEventPayload{
+			Match: match,
+			User:  swiperProfile,
+		}))
+		uc.notify(ctx, req.SwipedUserID, domain.NotificationNewMatch, &notification.NewMatchPayload{
+			MatchID:     match.ID,
+			UserID:      swiperID,
+			DisplayName: swiperProfile.DisplayName,
+		})
 	}
 
 	return response, nil
 }
 
-// createMatch creates a match between two users
-func (uc *SwipeUseCase) createMatch(ctx context.Context, user1ID, user2ID int) (*domain.Match, error) {
-	// Ensure user1_id < user2_id for database constraint
-	if user1ID > user2ID {
-		user1ID, user2ID = user2ID, user1ID
+// checkSwipeAllowed loads the swiper and makes sure the pair may interact.
+// Blocked users can't reach each other, not even by id.
+func (uc *SwipeUseCase) checkSwipeAllowed(ctx context.Context, repos *repository.TxRepositories, swiperID, swipedID int) (*domain.User, error) {
+	blocked, err := repos.Blocks.IsBlocked(ctx, swiperID, swipedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check block: %w", err)
+	}
+	if blocked {
+		return nil, domain.ErrUserNotFound
+	}
+
+	swiper, err := repos.Users.GetByID(ctx, swiperID)
+	if err != nil {
+		return nil, err
+	}
+	if swiper.IsBanned {
+		return nil, domain.ErrUserBanned
+	}
+
+	swiped, err := repos.Users.GetByID(ctx, swipedID)
+	if err != nil {
+		return nil, err
+	}
+	if swiped.IsBanned || swiped.IsShadowBanned {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return swiper, nil
+}
+
+// matchAfterPairUnlocked returns the active match of the pair, if any, once
+// no other swipe of the pair holds the lock
+func (uc *SwipeUseCase) matchAfterPairUnlocked(ctx context.Context, user1ID, user2ID int) (*domain.Match, error) {
+	var match *domain.Match
+	err := uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		if err := repos.Swipes.LockPair(ctx, user1ID, user2ID); err != nil {
+			return err
+		}
+
+		existing, err := repos.Matches.GetByUsers(ctx, user1ID, user2ID)
+		if errors.Is(err, domain.ErrMatchNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if existing.IsActive {
+			match = existing
+		}
+		return nil
+	})
+	return match, err
+}
+
+// publish pushes an event without failing the swipe on broker errors
+func (uc *SwipeUseCase) publish(ctx context.Context, userID int, event *domain.Event) {
+	if err := uc.publisher.Publish(ctx, userID, event); err != nil {
+		uc.log.WarnContext(ctx, "failed to publish event", "event", event.Type, "user_id", userID, "error", err)
 	}
+}
 
-	// Check if match already exists
-	existingMatch, err := uc.matchRepo.GetByUsers(ctx, user1ID, user2ID)
-	if err == nil && existingMatch != nil {
-		return existingMatch, nil
+// notify records a notification without failing the swipe on errors
+func (uc *SwipeUseCase) notify(ctx context.Context, userID int, notificationType domain.NotificationType, payload interface{}) {
+	if err := uc.notifier.Notify(ctx, userID, notificationType, payload); err != nil {
+		uc.log.WarnContext(ctx, "failed to notify user", "user_id", userID, "notification", notificationType, "error", err)
 	}
+}
 
+// createMatch creates a match for swiperID's like, or reactivates the one
+// their undo deactivated. A match ended by either user comes back inactive.
+func createMatch(ctx context.Context, matches repository.MatchRepository, swiperID, swipedID int) (*domain.Match, error) {
 	match := &domain.Match{
-		User1ID:  user1ID,
-		User2ID:  user2ID,
+		User1ID:  swiperID,
+		User2ID:  swipedID,
 		IsActive: true,
 	}
 
-	if err := uc.matchRepo.Create(ctx, match); err != nil {
+	if err := matches.Upsert(ctx, match, swiperID); err != nil {
 		return nil, err
 	}
 
@@ -180,15 +438,29 @@ func (uc *SwipeUseCase) getMatchedUserProfile(ctx context.Context, userID int) (
 		return nil, err
 	}
 
+	photos, err := uc.gallery.GetPhotos(ctx, []int{userID})
+	if err != nil {
+		uc.log.WarnContext(ctx, "failed to get photos", "user_id", userID, "error", err)
+	}
+
 	return &MatchedUserProfile{
 		ID:          profile.ID,
 		DisplayName: profile.DisplayName,
 		Bio:         profile.Bio,
 		City:        profile.City,
 		Age:         user.Age(),
+		Photos:      photosOf(photos, userID),
 	}, nil
 }
 
+// photosOf returns the user's photos, never nil so clients always get a list
+func photosOf(photos map[int][]*domain.Photo, userID int) []*domain.Photo {
+	if userPhotos := photos[userID]; userPhotos != nil {
+		return userPhotos
+	}
+	return []*domain.Photo{}
+}
+
 // GetLikesReceived returns list of users who liked current user
 func (uc *SwipeUseCase) GetLikesReceived(ctx context.Context, userID int, limit, offset int) ([]*LikeReceivedResponse, int, error) {
 	likes, err := uc.swipeRepo.GetLikesReceived(ctx, userID, limit, offset)
@@ -196,6 +468,15 @@ func (uc *SwipeUseCase) GetLikesReceived(ctx context.Context, userID int, limit,
 		return nil, 0, fmt.Errorf("failed to get likes received: %w", err)
 	}
 
+	swiperIDs := make([]int, 0, len(likes))
+	for _, like := range likes {
+		swiperIDs = append(swiperIDs, like.SwiperID)
+	}
+	photos, err := uc.gallery.GetPhotos(ctx, swiperIDs)
+	if err != nil {
+		uc.log.WarnContext(ctx, "failed to get photos for likes received", "error", err)
+	}
+
 	responses := make([]*LikeReceivedResponse, 0, len(likes))
 	for _, like := range likes {
 		// Get user profile
@@ -222,7 +503,8 @@ func (uc *SwipeUseCase) GetLikesReceived(ctx context.Context, userID int, limit,
 		}
 
 		responses = append(responses, &LikeReceivedResponse{
-			SwipeID: like.ID,
+			SwipeID:     like.ID,
+			IsSuperLike: like.IsSuperLike(),
 			User: &MatchedUserProfile{
 				ID:          profile.ID,
 				DisplayName: profile.DisplayName,
@@ -230,6 +512,7 @@ func (uc *SwipeUseCase) GetLikesReceived(ctx context.Context, userID int, limit,
 				City:        profile.City,
 				Age:         user.Age(),
 				DistanceKm:  distanceKm,
+				Photos:      photosOf(photos, like.SwiperID),
 			},
 			CreatedAt: like.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		})
@@ -273,26 +556,158 @@ func asinApprox(x float64) float64 {
 	return x + x*x*x/6 + 3*x*x*x*x*x/40
 }
 
-// updateUserPreferences implements Reinforcement Learning
-// It shifts the user's "Ideal Partner" vector towards the swiped user's traits
-func (uc *SwipeUseCase) updateUserPreferences(ctx context.Context, swiperID, swipedID int) {
-	// Get swiper profile
-	swiperProfile, err := uc.profileRepo.GetByUserID(ctx, swiperID)
+// UndoLastSwipe reverts the user's most recent swipe if it is still within
+// the undo window. Undoing a like deactivates the match it produced and
+// restores the preference vector from before the like.
+func (uc *SwipeUseCase) UndoLastSwipe(ctx context.Context, userID int) (*UndoSwipeResponse, error) {
+	swipe, err := uc.swipeRepo.GetLatestBySwiper(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Since(swipe.CreatedAt) > uc.undoWindow {
+		return nil, domain.ErrUndoWindowExpired
+	}
+
+	response := &UndoSwipeResponse{Swipe: swipe}
+
+	err = uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		if err := repos.Swipes.LockPair(ctx, swipe.SwiperID, swipe.SwipedID); err != nil {
+			return err
+		}
+
+		if swipe.IsLike {
+			// Wait for a running preference update of this like and restore
+			// the snapshot it saved, see updateUserPreferences
+			if err := restorePreferences(ctx, repos, swipe.ID, swipe.SwiperID); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Swipes.Delete(ctx, swipe.ID); err != nil {
+			return err
+		}
+
+		if !swipe.IsLike {
+			return nil
+		}
+
+		match, err := repos.Matches.GetByUsers(ctx, swipe.SwiperID, swipe.SwipedID)
+		if errors.Is(err, domain.ErrMatchNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if match.IsActive {
+			if err := repos.Matches.DeactivateByUndo(ctx, match.ID, swipe.SwiperID); err != nil {
+				return err
+			}
+			response.MatchDeactivated = true
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrSwipeNotFound) {
+			return nil, domain.ErrSwipeNotFound
+		}
+		return nil, fmt.Errorf("failed to undo swipe: %w", err)
+	}
+
+	// The undone profile has to come back into the feed
+	if err := uc.deck.InvalidateDeck(ctx, userID); err != nil {
+		uc.log.WarnContext(ctx, "failed to invalidate feed deck", "user_id", userID, "error", err)
+	}
+
+	return response, nil
+}
+
+// restorePreferences puts back the preference vector saved before the like
+// was learned. The profile row is locked first, so the snapshot read after it
+// is the one a concurrent update committed.
+func restorePreferences(ctx context.Context, repos *repository.TxRepositories, swipeID, swiperID int) error {
+	profile, err := repos.Profiles.GetByUserIDForUpdate(ctx, swiperID)
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		return nil
+	}
 	if err != nil {
-		return
+		return err
 	}
 
-	// Get swiped user profile (to get their traits)
-	swipedProfile, err := uc.profileRepo.GetByUserID(ctx, swipedID)
+	swipe, err := repos.Swipes.GetByID(ctx, swipeID)
 	if err != nil {
-		return
+		return err
+	}
+	if len(swipe.PrefSnapshot) == 0 {
+		return nil
 	}
 
-	// If swiped user doesn't have traits set (e.g. didn't take test), we can't learn
-	if swipedProfile.PrefOpenness == nil {
-		return
+	var prefs domain.PreferenceVector
+	if err := json.Unmarshal(swipe.PrefSnapshot, &prefs); err != nil {
+		return err
 	}
 
+	profile.SetPreferences(prefs)
+	return repos.Profiles.Update(ctx, profile)
+}
+
+// updateUserPreferences implements Reinforcement Learning
+// It shifts the user's "Ideal Partner" vector towards the swiped user's traits.
+// The snapshot and the new vector are saved in one transaction that holds the
+// swiper's profile row, so concurrent likes and undos apply one at a time.
+func (uc *SwipeUseCase) updateUserPreferences(ctx context.Context, swipeID, swiperID, swipedID int) error {
+	return uc.uow.Do(ctx, func(repos *repository.TxRepositories) error {
+		swiperProfile, err := repos.Profiles.GetByUserIDForUpdate(ctx, swiperID)
+		if err != nil {
+			return fmt.Errorf("failed to get swiper profile: %w", err)
+		}
+
+		// If the swipe is already undone there is nothing to learn. A snapshot
+		// means an earlier run already committed the update.
+		swipe, err := repos.Swipes.GetByID(ctx, swipeID)
+		if errors.Is(err, domain.ErrSwipeNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get swipe: %w", err)
+		}
+		if len(swipe.PrefSnapshot) > 0 {
+			return nil
+		}
+
+		// Get swiped user profile (to get their traits)
+		swipedProfile, err := repos.Profiles.GetByUserID(ctx, swipedID)
+		if err != nil {
+			return fmt.Errorf("failed to get swiped profile: %w", err)
+		}
+
+		// If swiped user doesn't have traits set (e.g. didn't take test), we can't learn
+		if swipedProfile.PrefOpenness == nil {
+			return nil
+		}
+
+		// Keep the current vector on the swipe so an undo can roll it back
+		snapshot, err := json.Marshal(swiperProfile.Preferences())
+		if err != nil {
+			return err
+		}
+		if err := repos.Swipes.SetPrefSnapshot(ctx, swipeID, snapshot); err != nil {
+			return fmt.Errorf("failed to save preference snapshot: %w", err)
+		}
+
+		learnPreferences(swiperProfile, swipedProfile)
+
+		if err := repos.Profiles.Update(ctx, swiperProfile); err != nil {
+			return fmt.Errorf("failed to update preferences: %w", err)
+		}
+		return nil
+	})
+}
+
+// learnPreferences moves the swiper's preference vector towards the traits
+// of the liked profile
+func learnPreferences(swiperProfile, swipedProfile *domain.Profile) {
+
 	// Learning rate (how fast we adapt)
 	const learningRate = 0.1
 
@@ -317,29 +732,36 @@ func (uc *SwipeUseCase) updateUserPreferences(ctx context.Context, swiperID, swi
 	swiperProfile.PrefExtraversion = updateTrait(swiperProfile.PrefExtraversion, swipedProfile.PrefExtraversion)
 	swiperProfile.PrefAgreeableness = updateTrait(swiperProfile.PrefAgreeableness, swipedProfile.PrefAgreeableness)
 	swiperProfile.PrefNeuroticism = updateTrait(swiperProfile.PrefNeuroticism, swipedProfile.PrefNeuroticism)
-
-	// Save updated profile
-	_ = uc.profileRepo.Update(ctx, swiperProfile)
 }
 
-func (uc *SwipeUseCase) enrichMatchWithAI(ctx context.Context, matchID, user1ID, user2ID int) {
-	fmt.Printf("🤖 [AI Wingman] Starting enrichMatchWithAI for match %d (users %d and %d)\n", matchID, user1ID, user2ID)
+// enrichMatchWithAI generates the match explanation and icebreakers and
+// lets both users know when they are ready. Unusable model output is replaced
+// with template texts right away, a failed call only on the final attempt,
+// before that the job is retried.
+func (uc *SwipeUseCase) enrichMatchWithAI(ctx context.Context, matchID, user1ID, user2ID int, finalAttempt bool) error {
+	// The match may have been undone or unmatched while the job waited
+	match, err := uc.matchRepo.GetByID(ctx, matchID)
+	if errors.Is(err, domain.ErrMatchNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get match: %w", err)
+	}
+	if !match.IsActive {
+		return nil
+	}
 
 	// Get profiles
 	p1, err := uc.profileRepo.GetByUserID(ctx, user1ID)
 	if err != nil {
-		fmt.Printf("❌ [AI Wingman] Failed to get profile for user %d: %v\n", user1ID, err)
-		return
+		return fmt.Errorf("failed to get profile of user %d: %w", user1ID, err)
 	}
 	p2, err := uc.profileRepo.GetByUserID(ctx, user2ID)
 	if err != nil {
-		fmt.Printf("❌ [AI Wingman] Failed to get profile for user %d: %v\n", user2ID, err)
-		return
+		return fmt.Errorf("failed to get profile of user %d: %w", user2ID, err)
 	}
 
-	fmt.Printf("✅ [AI Wingman] Got profiles: %s and %s\n", p1.DisplayName, p2.DisplayName)
-
-	// Prepare data for Gemini
+	// Prepare data for the AI wingman
 	traits1 := map[string]interface{}{
 		"Name":      p1.DisplayName,
 		"Interests": p1.Interests,
@@ -364,31 +786,68 @@ func (uc *SwipeUseCase) enrichMatchWithAI(ctx context.Context, matchID, user1ID,
 		}
 	}
 
-	// Generate Explanation
-	fmt.Printf("🔮 [AI Wingman] Calling Gemini for match explanation...\n")
-	explanation, err := uc.geminiClient.GenerateMatchExplanation(ctx, traits1, traits2)
+	var fallback bool
+
+	// Generate Explanation. Output of every provider goes through the same
+	// checks, an unusable answer is replaced like a failed call.
+	explanation, err := uc.wingman.GenerateMatchExplanation(ctx, traits1, traits2)
 	if err == nil {
-		fmt.Printf("✨ AI Explanation: %s\n", explanation)
-	} else {
-		fmt.Printf("❌ [AI Wingman] Failed to generate explanation: %v\n", err)
+		explanation = strings.TrimSpace(explanation)
+		err = llm.ValidateExplanation(explanation)
+	}
+	if err != nil {
+		if !canFallback(err, finalAttempt) {
+			return fmt.Errorf("failed to generate explanation: %w", err)
+		}
+		uc.log.WarnContext(ctx, "AI wingman failed, using fallback explanation", "match_id", matchID, "error", err)
+		metrics.AIFallbacks.WithLabelValues("match_explanation").Inc()
+		explanation, _ = uc.fallbackWingman.GenerateMatchExplanation(ctx, traits1, traits2)
+		fallback = true
 	}
 
 	// Generate Icebreakers (for User 1 to send to User 2)
-	fmt.Printf("🔮 [AI Wingman] Calling Gemini for icebreakers...\n")
-	icebreakers, err := uc.geminiClient.GenerateIcebreakers(ctx, p1.Interests, p2.Interests)
+	icebreakers, err := uc.wingman.GenerateIcebreakers(ctx, p1.Interests, p2.Interests)
 	if err == nil {
-		fmt.Printf("✨ AI Icebreakers: %v\n", icebreakers)
-	} else {
-		fmt.Printf("❌ [AI Wingman] Failed to generate icebreakers: %v\n", err)
+		icebreakers = llm.TrimAll(icebreakers)
+		err = llm.ValidateIcebreakers(icebreakers)
+	}
+	if err != nil {
+		if !canFallback(err, finalAttempt) {
+			return fmt.Errorf("failed to generate icebreakers: %w", err)
+		}
+		uc.log.WarnContext(ctx, "AI wingman failed, using fallback icebreakers", "match_id", matchID, "error", err)
+		metrics.AIFallbacks.WithLabelValues("icebreakers").Inc()
+		icebreakers, _ = uc.fallbackWingman.GenerateIcebreakers(ctx, p1.Interests, p2.Interests)
+		fallback = true
 	}
 
 	// Save AI content to database
-	if explanation != "" || len(icebreakers) > 0 {
-		err := uc.matchRepo.UpdateAIFields(ctx, matchID, explanation, icebreakers)
-		if err != nil {
-			fmt.Printf("❌ [AI Wingman] Failed to save AI content to DB: %v\n", err)
-		} else {
-			fmt.Printf("✅ [AI Wingman] AI content saved to DB successfully!\n")
-		}
+	if err := uc.matchRepo.UpdateAIFields(ctx, matchID, explanation, icebreakers, fallback); err != nil {
+		return fmt.Errorf("failed to save AI content: %w", err)
+	}
+	uc.log.DebugContext(ctx, "AI wingman: content saved",
+		"match_id", matchID, "has_explanation", explanation != "", "icebreakers", len(icebreakers), "fallback", fallback)
+
+	// Let both users know the icebreakers are ready. The event carries the
+	// content so open match screens update without refetching.
+	event := domain.NewEvent(domain.EventMatchAIReady, &MatchAIReadyPayload{
+		MatchID:     matchID,
+		Explanation: explanation,
+		Icebreakers: icebreakers,
+		Fallback:    fallback,
+	})
+	payload := &notification.IcebreakerReadyPayload{MatchID: matchID}
+	for _, userID := range []int{user1ID, user2ID} {
+		uc.publish(ctx, userID, event)
+		uc.notify(ctx, userID, domain.NotificationIcebreakerReady, payload)
 	}
+
+	return nil
+}
+
+// canFallback reports whether a wingman error should be answered with
+// template texts. Retrying helps with a failed call, not with a model
+// that keeps producing unusable output.
+func canFallback(err error, finalAttempt bool) bool {
+	return finalAttempt || errors.Is(err, llm.ErrInvalidOutput)
 }
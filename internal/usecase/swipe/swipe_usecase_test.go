@@ -0,0 +1,384 @@
+package swipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gdugdh24/mpit2026-backend/internal/domain"
+	"github.com/gdugdh24/mpit2026-backend/internal/infrastructure/llm"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository"
+	"github.com/gdugdh24/mpit2026-backend/internal/repository/postgres"
+	"github.com/gdugdh24/mpit2026-backend/internal/testutil/pgtest"
+	"github.com/jmoiron/sqlx"
+)
+
+// recordingPublisher remembers which users got which events
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events map[int][]domain.EventType
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, userID int, event *domain.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.events == nil {
+		p.events = make(map[int][]domain.EventType)
+	}
+	p.events[userID] = append(p.events[userID], event.Type)
+	return nil
+}
+
+func (p *recordingPublisher) received(userID int, eventType domain.EventType) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, t := range p.events[userID] {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(context.Context, int, domain.NotificationType, interface{}) error {
+	return nil
+}
+
+type nopDeck struct{}
+
+func (nopDeck) AdvanceDeck(context.Context, int, int) error { return nil }
+func (nopDeck) InvalidateDeck(context.Context, int) error   { return nil }
+
+type nopGallery struct{}
+
+func (nopGallery) GetPhotos(context.Context, []int) (map[int][]*domain.Photo, error) {
+	return nil, nil
+}
+
+func newTestSwipeUseCase(db *sqlx.DB, publisher *recordingPublisher) *SwipeUseCase {
+	return NewSwipeUseCase(
+		postgres.NewSwipeRepository(db),
+		postgres.NewMatchRepository(db),
+		postgres.NewUnitOfWork(db),
+		postgres.NewProfileRepository(db),
+		postgres.NewUserRepository(db),
+		postgres.NewBlockRepository(db),
+		postgres.NewQuotaRepository(db),
+		nil,
+		publisher,
+		nopNotifier{},
+		nopDeck{},
+		nopGallery{},
+		time.Minute,
+		DailyLimits{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+}
+
+// TestCreateSwipeSimultaneousMutualLikes fires opposite likes at the same time.
+// Exactly one match must exist and both responses must report it.
+func TestCreateSwipeSimultaneousMutualLikes(t *testing.T) {
+	const rounds = 20
+
+	db := pgtest.Open(t)
+	ctx := context.Background()
+
+	for round := 0; round < rounds; round++ {
+		publisher := &recordingPublisher{}
+		uc := newTestSwipeUseCase(db, publisher)
+
+		user1 := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+		user2 := pgtest.SeedUser(t, db, string(domain.GenderFemale), "Тест")
+
+		var (
+			wg        sync.WaitGroup
+			start     = make(chan struct{})
+			responses [2]*SwipeResponse
+			errs      [2]error
+		)
+		for i, pair := range [2][2]int{{user1, user2}, {user2, user1}} {
+			wg.Add(1)
+			go func(i, swiperID, swipedID int) {
+				defer wg.Done()
+				<-start
+				responses[i], errs[i] = uc.CreateSwipe(ctx, swiperID, &SwipeRequest{
+					SwipedUserID: swipedID,
+					Direction:    domain.SwipeRight,
+				})
+			}(i, pair[0], pair[1])
+		}
+		close(start)
+		wg.Wait()
+
+		for i, err := range errs {
+			if err != nil {
+				t.Fatalf("round %d: swipe %d: %v", round, i, err)
+			}
+		}
+
+		var matches int
+		err := db.GetContext(ctx, &matches, `
+			SELECT COUNT(*) FROM matches
+			WHERE user1_id = LEAST($1::int, $2::int) AND user2_id = GREATEST($1::int, $2::int) AND is_active
+		`, user1, user2)
+		if err != nil {
+			t.Fatalf("count matches: %v", err)
+		}
+		if matches != 1 {
+			t.Fatalf("round %d: got %d active matches, want 1", round, matches)
+		}
+
+		for i, userID := range []int{user1, user2} {
+			if !responses[i].IsMatch || responses[i].Match == nil {
+				t.Errorf("round %d: response of user %d doesn't report the match", round, userID)
+			}
+			if !publisher.received(userID, domain.EventMatchNew) {
+				t.Errorf("round %d: user %d got no match.new event", round, userID)
+			}
+		}
+	}
+}
+
+// setTraits sets all five preference values of a seeded profile
+func setTraits(t *testing.T, db *sqlx.DB, userID int, value float64) {
+	t.Helper()
+	_, err := db.ExecContext(context.Background(), `
+		UPDATE profiles SET pref_openness = $2, pref_conscientiousness = $2, pref_extraversion = $2,
+			pref_agreeableness = $2, pref_neuroticism = $2
+		WHERE user_id = $1
+	`, userID, value)
+	if err != nil {
+		t.Fatalf("set traits: %v", err)
+	}
+}
+
+func like(t *testing.T, db *sqlx.DB, swiperID, swipedID int) int {
+	t.Helper()
+	swipe := &domain.Swipe{SwiperID: swiperID, SwipedID: swipedID, IsLike: true, Direction: domain.SwipeRight}
+	if err := postgres.NewSwipeRepository(db).Create(context.Background(), swipe); err != nil {
+		t.Fatalf("create swipe: %v", err)
+	}
+	return swipe.ID
+}
+
+func openness(t *testing.T, db *sqlx.DB, userID int) float64 {
+	t.Helper()
+	var value float64
+	if err := db.GetContext(context.Background(), &value, `SELECT pref_openness FROM profiles WHERE user_id = $1`, userID); err != nil {
+		t.Fatalf("get openness: %v", err)
+	}
+	return value
+}
+
+func TestUpdateUserPreferences(t *testing.T) {
+	const tolerance = 1e-9
+
+	db := pgtest.Open(t)
+	ctx := context.Background()
+	uc := newTestSwipeUseCase(db, &recordingPublisher{})
+
+	seed := func(value float64) int {
+		userID := pgtest.SeedUser(t, db, string(domain.GenderFemale), "Тест")
+		setTraits(t, db, userID, value)
+		return userID
+	}
+	high, low := seed(1), seed(0)
+
+	t.Run("retried job learns once", func(t *testing.T) {
+		swiper := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+		setTraits(t, db, swiper, 0.5)
+		swipeID := like(t, db, swiper, high)
+
+		for run := 0; run < 2; run++ {
+			if err := uc.updateUserPreferences(ctx, swipeID, swiper, high); err != nil {
+				t.Fatalf("run %d: %v", run, err)
+			}
+		}
+		if got := openness(t, db, swiper); math.Abs(got-0.55) > tolerance {
+			t.Fatalf("openness = %v, want 0.55", got)
+		}
+
+		// Undo puts back the vector from before the like
+		if _, err := uc.UndoLastSwipe(ctx, swiper); err != nil {
+			t.Fatalf("undo: %v", err)
+		}
+		if got := openness(t, db, swiper); math.Abs(got-0.5) > tolerance {
+			t.Fatalf("openness after undo = %v, want 0.5", got)
+		}
+	})
+
+	t.Run("concurrent likes both apply", func(t *testing.T) {
+		swiper := pgtest.SeedUser(t, db, string(domain.GenderMale), "Тест")
+		setTraits(t, db, swiper, 0.5)
+		targets := []int{high, low}
+		swipeIDs := []int{like(t, db, swiper, high), like(t, db, swiper, low)}
+
+		var wg sync.WaitGroup
+		errs := make([]error, len(targets))
+		for i := range targets {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = uc.updateUserPreferences(ctx, swipeIDs[i], swiper, targets[i])
+			}(i)
+		}
+		wg.Wait()
+		for i, err := range errs {
+			if err != nil {
+				t.Fatalf("update %d: %v", i, err)
+			}
+		}
+
+		// 0.5 -> 0.55 -> 0.495 or 0.5 -> 0.45 -> 0.505, a lost update leaves 0.55 or 0.45
+		got := openness(t, db, swiper)
+		if math.Abs(got-0.495) > tolerance && math.Abs(got-0.505) > tolerance {
+			t.Fatalf("openness = %v, want 0.495 or 0.505", got)
+		}
+	})
+}
+
+// memoryMatches serves one match and records the AI fields saved for it
+type memoryMatches struct {
+	repository.MatchRepository
+	match *domain.Match
+}
+
+func (r *memoryMatches) GetByID(context.Context, int) (*domain.Match, error) {
+	return r.match, nil
+}
+
+func (r *memoryMatches) UpdateAIFields(_ context.Context, _ int, explanation string, icebreakers []string, fallback bool) error {
+	r.match.Explanation = &explanation
+	r.match.Icebreakers = icebreakers
+	r.match.AIFallback = fallback
+	return nil
+}
+
+type memoryProfiles struct {
+	repository.ProfileRepository
+	profiles map[int]*domain.Profile
+}
+
+func (r *memoryProfiles) GetByUserID(_ context.Context, userID int) (*domain.Profile, error) {
+	profile, ok := r.profiles[userID]
+	if !ok {
+		return nil, domain.ErrProfileNotFound
+	}
+	return profile, nil
+}
+
+// failingWingman stands in for a provider whose every call fails with err
+type failingWingman struct {
+	err error
+}
+
+func (w failingWingman) GenerateMatchExplanation(context.Context, map[string]interface{}, map[string]interface{}) (string, error) {
+	return "", w.err
+}
+
+func (w failingWingman) GenerateIcebreakers(context.Context, []string, []string) ([]string, error) {
+	return nil, w.err
+}
+
+func (w failingWingman) GenerateBio(context.Context, string, []string, string) (map[string]string, error) {
+	return nil, w.err
+}
+
+// englishWingman answers without errors, but not in Russian and with too
+// few icebreakers, like a provider ignoring the prompt
+type englishWingman struct {
+	failingWingman
+}
+
+func (englishWingman) GenerateMatchExplanation(context.Context, map[string]interface{}, map[string]interface{}) (string, error) {
+	return "You both love movies.", nil
+}
+
+func (englishWingman) GenerateIcebreakers(context.Context, []string, []string) ([]string, error) {
+	return []string{"Обсудите любимый фильм"}, nil
+}
+
+func TestEnrichMatchWithAIFallback(t *testing.T) {
+	invalid := fmt.Errorf("%w: failed to parse icebreakers", llm.ErrInvalidOutput)
+	unavailable := errors.New("provider unavailable")
+
+	tests := []struct {
+		name         string
+		wingman      llm.AIWingman
+		finalAttempt bool
+		wantErr      bool
+		wantFallback bool
+	}{
+		{name: "provider succeeds", wingman: llm.NewFakeWingman()},
+		{name: "invalid output falls back at once", wingman: failingWingman{err: invalid}, wantFallback: true},
+		{name: "output failing validation falls back at once", wingman: englishWingman{}, wantFallback: true},
+		{name: "failed call is retried", wingman: failingWingman{err: unavailable}, wantErr: true},
+		{name: "failed call falls back on the final attempt", wingman: failingWingman{err: unavailable}, finalAttempt: true, wantFallback: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			matches := &memoryMatches{match: &domain.Match{ID: 7, User1ID: 1, User2ID: 2, IsActive: true}}
+			profiles := &memoryProfiles{profiles: map[int]*domain.Profile{
+				1: {UserID: 1, DisplayName: "Анна", Interests: []string{"кино", "походы"}},
+				2: {UserID: 2, DisplayName: "Илья", Interests: []string{"кино", "шахматы"}},
+			}}
+			publisher := &recordingPublisher{}
+			uc := NewSwipeUseCase(
+				nil, matches, nil, profiles, nil, nil, nil,
+				tt.wingman,
+				publisher,
+				nopNotifier{},
+				nopDeck{},
+				nopGallery{},
+				time.Minute,
+				DailyLimits{},
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+			)
+
+			err := uc.enrichMatchWithAI(ctx, 7, 1, 2, tt.finalAttempt)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error to retry the job")
+				}
+				if matches.match.Explanation != nil || publisher.received(1, domain.EventMatchAIReady) {
+					t.Fatal("AI content saved although the job is retried")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("enrich: %v", err)
+			}
+
+			match := matches.match
+			if match.AIFallback != tt.wantFallback {
+				t.Fatalf("fallback = %v, want %v", match.AIFallback, tt.wantFallback)
+			}
+			if match.Explanation == nil || *match.Explanation == "" || len(match.Icebreakers) != 3 {
+				t.Fatalf("got explanation %v and %d icebreakers, want both filled", match.Explanation, len(match.Icebreakers))
+			}
+
+			// The fallback texts are exactly what FakeWingman writes
+			fake := llm.NewFakeWingman()
+			wantIcebreakers, _ := fake.GenerateIcebreakers(ctx, profiles.profiles[1].Interests, profiles.profiles[2].Interests)
+			if fmt.Sprint(match.Icebreakers) != fmt.Sprint(wantIcebreakers) {
+				t.Fatalf("icebreakers = %q, want %q", match.Icebreakers, wantIcebreakers)
+			}
+
+			for _, userID := range []int{1, 2} {
+				if !publisher.received(userID, domain.EventMatchAIReady) {
+					t.Errorf("user %d did not get match.ai_ready", userID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_notifications_user_created;
+
+ALTER TABLE notifications
+ADD COLUMN content TEXT NOT NULL DEFAULT '';
+
+UPDATE notifications SET content = COALESCE(payload->>'text', type);
+
+ALTER TABLE notifications
+DROP COLUMN IF EXISTS payload,
+DROP COLUMN IF EXISTS type;
@@ -0,0 +1,13 @@
+-- Typed notifications with JSON payload instead of plain text content
+ALTER TABLE notifications
+ADD COLUMN type VARCHAR(50) NOT NULL DEFAULT 'system'
+    CHECK (type IN ('system', 'new_match', 'new_like', 'new_message', 'icebreaker_ready', 'moderation_result')),
+ADD COLUMN payload JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Keep existing text notifications readable
+UPDATE notifications SET payload = jsonb_build_object('text', content);
+
+ALTER TABLE notifications
+DROP COLUMN content;
+
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC);
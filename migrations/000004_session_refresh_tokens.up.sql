@@ -0,0 +1,11 @@
+-- Sessions now store hashed refresh tokens; every refresh rotates the token
+-- into a new row of the same family so reuse of an old token can be detected.
+ALTER TABLE sessions
+ADD COLUMN family_id UUID NOT NULL DEFAULT gen_random_uuid(),
+ADD COLUMN rotated_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN revoked_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_sessions_family_id ON sessions(family_id);
+
+-- Old rows hold access token hashes and can't be refreshed
+UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP;
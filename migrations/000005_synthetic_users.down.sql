@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_is_synthetic;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS is_synthetic;
@@ -0,0 +1,6 @@
+-- Users created through the /auth/test bypass are synthetic and excluded
+-- from the feed outside development/test environments
+ALTER TABLE users
+ADD COLUMN is_synthetic BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_users_is_synthetic ON users(is_synthetic) WHERE is_synthetic = true;
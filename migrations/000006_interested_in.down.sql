@@ -0,0 +1,19 @@
+-- Non-binary users can't be represented in the old schema. Refuse to roll
+-- back instead of losing them, they have to be migrated by hand first.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users WHERE gender NOT IN ('male', 'female')) THEN
+        RAISE EXCEPTION 'cannot roll back 000006: % users have a gender other than male or female',
+            (SELECT COUNT(*) FROM users WHERE gender NOT IN ('male', 'female'));
+    END IF;
+END
+$$;
+
+DROP INDEX IF EXISTS idx_profiles_interested_in;
+
+ALTER TABLE profiles
+DROP COLUMN IF EXISTS interested_in;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_gender_check;
+ALTER TABLE users
+ADD CONSTRAINT users_gender_check CHECK (gender IN ('male', 'female'));
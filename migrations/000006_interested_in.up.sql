@@ -0,0 +1,17 @@
+-- Allow non-binary users
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_gender_check;
+ALTER TABLE users
+ADD CONSTRAINT users_gender_check CHECK (gender IN ('male', 'female', 'non_binary'));
+
+-- Who the user wants to see in the feed
+ALTER TABLE profiles
+ADD COLUMN interested_in VARCHAR(10) NOT NULL DEFAULT 'everyone'
+    CHECK (interested_in IN ('men', 'women', 'everyone'));
+
+-- Keep the previous opposite-gender behaviour for existing profiles
+UPDATE profiles p
+SET interested_in = CASE u.gender WHEN 'male' THEN 'women' ELSE 'men' END
+FROM users u
+WHERE u.id = p.user_id AND u.gender IN ('male', 'female');
+
+CREATE INDEX idx_profiles_interested_in ON profiles(interested_in);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_swipes_dislikes;
+
+ALTER TABLE swipes
+DROP COLUMN IF EXISTS pref_snapshot;
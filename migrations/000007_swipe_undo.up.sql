@@ -0,0 +1,6 @@
+-- Preference vector of the swiper before a like was learned,
+-- restored when the swipe is undone
+ALTER TABLE swipes
+ADD COLUMN pref_snapshot JSONB;
+
+CREATE INDEX idx_swipes_dislikes ON swipes(swiper_id, created_at) WHERE is_like = false;
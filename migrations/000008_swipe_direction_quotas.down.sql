@@ -0,0 +1,10 @@
+ALTER TABLE users
+DROP COLUMN IF EXISTS timezone;
+
+DROP INDEX IF EXISTS idx_swipes_swiper_direction;
+
+ALTER TABLE swipes
+DROP CONSTRAINT IF EXISTS swipes_direction_is_like;
+
+ALTER TABLE swipes
+DROP COLUMN IF EXISTS direction;
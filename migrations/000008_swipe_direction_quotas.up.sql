@@ -0,0 +1,17 @@
+-- Swipe direction: left = dislike, right = like, super = super-like.
+-- is_like is kept for existing queries and must agree with direction.
+ALTER TABLE swipes
+ADD COLUMN direction VARCHAR(10) NOT NULL DEFAULT 'left'
+    CHECK (direction IN ('left', 'right', 'super'));
+
+UPDATE swipes SET direction = 'right' WHERE is_like = true;
+
+ALTER TABLE swipes
+ADD CONSTRAINT swipes_direction_is_like CHECK ((direction <> 'left') = is_like);
+
+-- Daily quota counting
+CREATE INDEX idx_swipes_swiper_direction ON swipes(swiper_id, direction, created_at);
+
+-- Daily quotas reset at midnight in the user's timezone
+ALTER TABLE users
+ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'Europe/Moscow';
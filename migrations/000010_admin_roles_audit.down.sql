@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+DROP TRIGGER IF EXISTS audit_log_no_update ON audit_log;
+DROP FUNCTION IF EXISTS audit_log_immutable();
+DROP TABLE IF EXISTS audit_log;
+
+DROP INDEX IF EXISTS idx_users_role;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS is_shadow_banned,
+DROP COLUMN IF EXISTS is_banned,
+DROP COLUMN IF EXISTS role;
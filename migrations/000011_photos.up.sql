@@ -0,0 +1,18 @@
+-- Profile photos, files live in the storage driver under storage_key
+CREATE TABLE photos (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    storage_key VARCHAR(255) NOT NULL,
+    thumbnail_key VARCHAR(255) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_photos_user_id ON photos(user_id, position);
+
+-- At most one primary photo per user
+CREATE UNIQUE INDEX idx_photos_primary ON photos(user_id) WHERE is_primary;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_photos_source_url;
+ALTER TABLE photos DROP COLUMN IF EXISTS source_url;
@@ -0,0 +1,5 @@
+-- Where an imported photo came from, so imports are not repeated.
+-- NULL for photos uploaded by the user.
+ALTER TABLE photos ADD COLUMN source_url TEXT;
+
+CREATE UNIQUE INDEX idx_photos_source_url ON photos(user_id, source_url) WHERE source_url IS NOT NULL;
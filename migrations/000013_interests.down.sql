@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS interests;
+DROP TABLE IF EXISTS interest_categories;
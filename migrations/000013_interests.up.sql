@@ -0,0 +1,120 @@
+-- Interest catalog. profiles.interests stores interest slugs, names and
+-- synonyms (Russian and English, lowercase) are what users type.
+CREATE TABLE interest_categories (
+    slug VARCHAR(50) PRIMARY KEY,
+    name_ru VARCHAR(100) NOT NULL,
+    name_en VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE interests (
+    slug VARCHAR(50) PRIMARY KEY,
+    category_slug VARCHAR(50) NOT NULL REFERENCES interest_categories(slug) ON UPDATE CASCADE,
+    name_ru VARCHAR(100) NOT NULL,
+    name_en VARCHAR(100) NOT NULL,
+    synonyms TEXT[] NOT NULL DEFAULT '{}',
+    position INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_interests_category ON interests(category_slug, position);
+
+INSERT INTO interest_categories (slug, name_ru, name_en, position) VALUES
+    ('music', 'Музыка', 'Music', 1),
+    ('sport', 'Спорт', 'Sports', 2),
+    ('outdoors', 'Природа и активный отдых', 'Outdoors', 3),
+    ('arts', 'Искусство', 'Arts', 4),
+    ('screen', 'Кино и сериалы', 'Movies & TV', 5),
+    ('reading', 'Книги', 'Books', 6),
+    ('games', 'Игры', 'Games', 7),
+    ('travel', 'Путешествия', 'Travel', 8),
+    ('food', 'Еда и напитки', 'Food & Drink', 9),
+    ('tech', 'Технологии и наука', 'Tech & Science', 10),
+    ('lifestyle', 'Образ жизни', 'Lifestyle', 11),
+    ('pets', 'Животные', 'Pets', 12);
+
+INSERT INTO interests (slug, category_slug, name_ru, name_en, synonyms, position) VALUES
+    ('music', 'music', 'Музыка', 'Music', '{меломан,слушать музыку}', 1),
+    ('concerts', 'music', 'Концерты', 'Concerts', '{концерт,фестивали,live music,gigs}', 2),
+    ('guitar', 'music', 'Гитара', 'Guitar', '{игра на гитаре}', 3),
+    ('piano', 'music', 'Фортепиано', 'Piano', '{пианино}', 4),
+    ('singing', 'music', 'Пение', 'Singing', '{вокал,vocals}', 5),
+    ('karaoke', 'music', 'Караоке', 'Karaoke', '{}', 6),
+    ('djing', 'music', 'Диджеинг', 'DJing', '{диджей,dj}', 7),
+
+    ('sports', 'sport', 'Спорт', 'Sports', '{sport,спортивный образ жизни}', 0),
+    ('fitness', 'sport', 'Фитнес', 'Fitness', '{спортзал,тренажерный зал,качалка,gym,workout}', 1),
+    ('running', 'sport', 'Бег', 'Running', '{пробежки,марафоны,jogging}', 2),
+    ('yoga', 'sport', 'Йога', 'Yoga', '{}', 3),
+    ('football', 'sport', 'Футбол', 'Football', '{soccer}', 4),
+    ('basketball', 'sport', 'Баскетбол', 'Basketball', '{}', 5),
+    ('volleyball', 'sport', 'Волейбол', 'Volleyball', '{}', 6),
+    ('tennis', 'sport', 'Теннис', 'Tennis', '{большой теннис,настольный теннис,падел}', 7),
+    ('swimming', 'sport', 'Плавание', 'Swimming', '{бассейн,pool}', 8),
+    ('martial_arts', 'sport', 'Единоборства', 'Martial arts', '{бокс,борьба,карате,дзюдо,boxing,mma}', 9),
+    ('dancing', 'sport', 'Танцы', 'Dancing', '{dance,сальса,бачата}', 10),
+
+    ('hiking', 'outdoors', 'Походы', 'Hiking', '{поход,туризм,трекинг,trekking}', 1),
+    ('cycling', 'outdoors', 'Велосипед', 'Cycling', '{велоспорт,велопрогулки,bike,bicycle}', 2),
+    ('climbing', 'outdoors', 'Скалолазание', 'Climbing', '{боулдеринг,альпинизм,bouldering}', 3),
+    ('skiing', 'outdoors', 'Лыжи', 'Skiing', '{горные лыжи}', 4),
+    ('snowboarding', 'outdoors', 'Сноуборд', 'Snowboarding', '{snowboard}', 5),
+    ('fishing', 'outdoors', 'Рыбалка', 'Fishing', '{}', 6),
+    ('camping', 'outdoors', 'Кемпинг', 'Camping', '{палатки}', 7),
+    ('gardening', 'outdoors', 'Садоводство', 'Gardening', '{сад,огород,дача,растения,plants}', 8),
+    ('nature', 'outdoors', 'Природа', 'Nature', '{прогулки,walks}', 9),
+
+    ('art', 'arts', 'Искусство', 'Art', '{}', 0),
+    ('drawing', 'arts', 'Рисование', 'Drawing', '{живопись,painting}', 1),
+    ('photography', 'arts', 'Фотография', 'Photography', '{фото,фотографировать,photo}', 2),
+    ('design', 'arts', 'Дизайн', 'Design', '{}', 3),
+    ('museums', 'arts', 'Музеи', 'Museums', '{выставки,галереи,exhibitions}', 4),
+    ('theatre', 'arts', 'Театр', 'Theatre', '{theater,спектакли,опера,балет}', 5),
+    ('handmade', 'arts', 'Рукоделие', 'Crafts', '{хендмейд,вязание,crafts,diy}', 6),
+    ('fashion', 'arts', 'Мода', 'Fashion', '{стиль,style}', 7),
+
+    ('movies', 'screen', 'Кино', 'Movies', '{фильмы,кинотеатр,cinema,films}', 1),
+    ('tv_series', 'screen', 'Сериалы', 'TV series', '{сериал,tv shows,series}', 2),
+    ('anime', 'screen', 'Аниме', 'Anime', '{манга,manga}', 3),
+    ('stand_up', 'screen', 'Стендап', 'Stand-up', '{stand up,юмор,comedy}', 4),
+
+    ('books', 'reading', 'Книги', 'Books', '{чтение,литература,reading,literature}', 1),
+    ('poetry', 'reading', 'Поэзия', 'Poetry', '{стихи}', 2),
+    ('comics', 'reading', 'Комиксы', 'Comics', '{}', 3),
+    ('history', 'reading', 'История', 'History', '{}', 4),
+
+    ('video_games', 'games', 'Видеоигры', 'Video games', '{игры,компьютерные игры,gaming,games,геймер}', 1),
+    ('board_games', 'games', 'Настольные игры', 'Board games', '{настолки}', 2),
+    ('chess', 'games', 'Шахматы', 'Chess', '{}', 3),
+    ('quizzes', 'games', 'Квизы', 'Quizzes', '{квиз,квесты,quiz,pub quiz}', 4),
+    ('esports', 'games', 'Киберспорт', 'Esports', '{e-sports}', 5),
+
+    ('travel', 'travel', 'Путешествия', 'Travel', '{путешествовать,travelling,traveling}', 1),
+    ('road_trips', 'travel', 'Автопутешествия', 'Road trips', '{роудтрипы,road trip}', 2),
+    ('languages', 'travel', 'Иностранные языки', 'Languages', '{языки,изучение языков,language learning}', 3),
+    ('cars', 'travel', 'Автомобили', 'Cars', '{машины,авто}', 4),
+
+    ('cooking', 'food', 'Кулинария', 'Cooking', '{готовка,готовить}', 1),
+    ('baking', 'food', 'Выпечка', 'Baking', '{}', 2),
+    ('coffee', 'food', 'Кофе', 'Coffee', '{кофейни}', 3),
+    ('tea', 'food', 'Чай', 'Tea', '{}', 4),
+    ('wine', 'food', 'Вино', 'Wine', '{}', 5),
+    ('restaurants', 'food', 'Рестораны', 'Restaurants', '{гастрономия,фудблогинг,foodie}', 6),
+    ('vegan', 'food', 'Веганство', 'Vegan', '{вегетарианство,vegetarian}', 7),
+
+    ('programming', 'tech', 'Программирование', 'Programming', '{it,coding,айти,разработка}', 1),
+    ('science', 'tech', 'Наука', 'Science', '{}', 2),
+    ('space', 'tech', 'Космос', 'Space', '{астрономия,astronomy}', 3),
+    ('startups', 'tech', 'Стартапы', 'Startups', '{бизнес,предпринимательство,business}', 4),
+    ('gadgets', 'tech', 'Гаджеты', 'Gadgets', '{техника}', 5),
+
+    ('meditation', 'lifestyle', 'Медитация', 'Meditation', '{mindfulness,осознанность}', 1),
+    ('psychology', 'lifestyle', 'Психология', 'Psychology', '{саморазвитие,self-development}', 2),
+    ('volunteering', 'lifestyle', 'Волонтерство', 'Volunteering', '{волонтер,благотворительность,charity}', 3),
+    ('astrology', 'lifestyle', 'Астрология', 'Astrology', '{гороскопы,таро,tarot}', 4),
+    ('parties', 'lifestyle', 'Вечеринки', 'Parties', '{клубы,тусовки,nightlife}', 5),
+    ('healthy_lifestyle', 'lifestyle', 'ЗОЖ', 'Healthy lifestyle', '{здоровый образ жизни,пп,wellness}', 6),
+
+    ('dogs', 'pets', 'Собаки', 'Dogs', '{собака,dog}', 1),
+    ('cats', 'pets', 'Кошки', 'Cats', '{коты,котики,кошка,cat}', 2),
+    ('animals', 'pets', 'Животные', 'Animals', '{питомцы,pets}', 3),
+    ('horses', 'pets', 'Лошади', 'Horses', '{верховая езда,horse riding}', 4);
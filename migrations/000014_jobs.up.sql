@@ -0,0 +1,21 @@
+-- Durable background jobs. Workers claim due rows with FOR UPDATE SKIP LOCKED
+-- and hold a lease (locked_until), a job whose worker died is claimed again
+-- once the lease expires. Finished jobs are deleted, jobs that ran out of
+-- attempts stay as 'dead' for inspection.
+CREATE TABLE jobs (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_jobs_due ON jobs(run_at) WHERE status = 'pending';
+CREATE INDEX idx_jobs_lease ON jobs(locked_until) WHERE status = 'running';
+CREATE INDEX idx_jobs_dead ON jobs(type, updated_at) WHERE status = 'dead';
@@ -0,0 +1,3 @@
+-- Set when the match explanation or icebreakers come from templates
+-- because the AI provider failed, so clients can show them differently.
+ALTER TABLE matches ADD COLUMN ai_fallback BOOLEAN NOT NULL DEFAULT FALSE;
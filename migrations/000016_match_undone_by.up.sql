@@ -0,0 +1,4 @@
+-- Set when a match was deactivated by undoing the like that created it.
+-- Only a new like of the same user brings such a match back, a match ended
+-- with DELETE /matches/:id stays ended.
+ALTER TABLE matches ADD COLUMN undone_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
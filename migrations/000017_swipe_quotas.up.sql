@@ -0,0 +1,10 @@
+-- Daily like and super-like usage, counted apart from swipes: undoing a swipe
+-- doesn't give it back. resets_at is fixed when the first swipe of a window is
+-- counted, so changing the timezone doesn't reset the count either.
+CREATE TABLE swipe_quotas (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    direction VARCHAR(10) NOT NULL CHECK (direction IN ('right', 'super')),
+    used INTEGER NOT NULL,
+    resets_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, direction)
+);
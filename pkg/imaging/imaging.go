@@ -0,0 +1,150 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// Supported upload content types, detected from the file contents
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeWebP = "image/webp"
+)
+
+// maxPixels protects against decompression bombs, a small file can
+// declare a huge canvas
+const maxPixels = 50_000_000
+
+const jpegQuality = 85
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrTooLarge          = errors.New("image dimensions too large")
+)
+
+// Image is an encoded JPEG without any metadata
+type Image struct {
+	Data   []byte
+	Width  int
+	Height int
+}
+
+// DetectType sniffs the content type from the first bytes of the file,
+// the client-provided Content-Type is not trusted
+func DetectType(data []byte) (string, error) {
+	switch contentType := http.DetectContentType(data); contentType {
+	case TypeJPEG, TypePNG, TypeWebP:
+		return contentType, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Process decodes an uploaded image and re-encodes it as JPEG no larger
+// than maxSide, plus a thumbnail no larger than thumbSide. Re-encoding drops
+// EXIF and other metadata (GPS included), the EXIF orientation is applied to
+// the pixels first so photos from phones are not rotated.
+func Process(data []byte, maxSide, thumbSide int) (full, thumb *Image, err error) {
+	contentType, err := DetectType(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := decodeConfig(data, contentType)
+	if err != nil {
+		return nil, nil, ErrUnsupportedFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, nil, ErrUnsupportedFormat
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, nil, ErrTooLarge
+	}
+
+	img, err := decode(data, contentType)
+	if err != nil {
+		return nil, nil, ErrUnsupportedFormat
+	}
+
+	orientation := 1
+	if contentType == TypeJPEG {
+		orientation = jpegOrientation(data)
+	}
+
+	full, err = encode(orient(fit(img, maxSide), orientation))
+	if err != nil {
+		return nil, nil, err
+	}
+	thumb, err = encode(orient(fit(img, thumbSide), orientation))
+	if err != nil {
+		return nil, nil, err
+	}
+	return full, thumb, nil
+}
+
+func decodeConfig(data []byte, contentType string) (image.Config, error) {
+	r := bytes.NewReader(data)
+	switch contentType {
+	case TypeJPEG:
+		return jpeg.DecodeConfig(r)
+	case TypePNG:
+		return png.DecodeConfig(r)
+	default:
+		return webp.DecodeConfig(r)
+	}
+}
+
+func decode(data []byte, contentType string) (image.Image, error) {
+	r := bytes.NewReader(data)
+	switch contentType {
+	case TypeJPEG:
+		return jpeg.Decode(r)
+	case TypePNG:
+		return png.Decode(r)
+	default:
+		return webp.Decode(r)
+	}
+}
+
+// fit scales the image down so its longer side is at most maxSide.
+// The result is always a fresh RGBA on a white background, so transparent
+// PNGs don't turn black in JPEG.
+func fit(img image.Image, maxSide int) *image.RGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > maxSide || h > maxSide {
+		if w >= h {
+			h = max(1, h*maxSide/w)
+			w = maxSide
+		} else {
+			w = max(1, w*maxSide/h)
+			h = maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Over, nil)
+	return dst
+}
+
+func encode(img *image.RGBA) (*Image, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return &Image{
+		Data:   buf.Bytes(),
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}, nil
+}
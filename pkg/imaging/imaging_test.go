@@ -0,0 +1,169 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// testJPEG encodes a 40x20 image, red on the left half and blue on the right
+func testJPEG(t *testing.T) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			c := color.RGBA{B: 255, A: 255}
+			if x < 20 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// exifSegment builds an APP1 segment with the orientation tag in IFD0 and
+// a GPS IFD with the latitude, like phone cameras write
+func exifSegment(orientation int) []byte {
+	order := binary.BigEndian
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+
+	entry := func(tag, typ uint16, count, value uint32) []byte {
+		b := make([]byte, 12)
+		order.PutUint16(b, tag)
+		order.PutUint16(b[2:], typ)
+		order.PutUint32(b[4:], count)
+		order.PutUint32(b[8:], value)
+		return b
+	}
+
+	// IFD0 at offset 8: 2 entries, next IFD offset, GPS IFD right after it
+	gpsOffset := uint32(8 + 2 + 2*12 + 4)
+	tiff = append(tiff, 0x00, 0x02)
+	tiff = append(tiff, entry(orientationTag, 3, 1, uint32(orientation)<<16)...)
+	tiff = append(tiff, entry(0x8825, 4, 1, gpsOffset)...)
+	tiff = append(tiff, 0, 0, 0, 0)
+
+	// GPS IFD: GPSLatitudeRef "N" and GPSLatitude as three rationals
+	latOffset := gpsOffset + 2 + 2*12 + 4
+	tiff = append(tiff, 0x00, 0x02)
+	tiff = append(tiff, entry(0x0001, 2, 2, 'N'<<24)...)
+	tiff = append(tiff, entry(0x0002, 5, 3, latOffset)...)
+	tiff = append(tiff, 0, 0, 0, 0)
+	for _, v := range []uint32{55, 1, 45, 1, 2100, 100} {
+		tiff = binary.BigEndian.AppendUint32(tiff, v)
+	}
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	order.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// withEXIF inserts the segment right after the SOI marker
+func withEXIF(jpegData, segment []byte) []byte {
+	out := append([]byte{}, jpegData[:2]...)
+	out = append(out, segment...)
+	return append(out, jpegData[2:]...)
+}
+
+// segmentMarkers lists the markers of the segments before the image data
+func segmentMarkers(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var markers []byte
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			t.Fatalf("broken JPEG segment at %d", pos)
+		}
+		marker := data[pos+1]
+		markers = append(markers, marker)
+		if marker == 0xDA {
+			break
+		}
+		pos += 2 + int(binary.BigEndian.Uint16(data[pos+2:]))
+	}
+	return markers
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r > 0xC000 && g < 0x4000 && b < 0x4000
+}
+
+func TestProcessStripsEXIFAndAppliesOrientation(t *testing.T) {
+	plain := testJPEG(t)
+
+	tests := []struct {
+		name       string
+		data       []byte
+		wantOrient int
+		wantW      int
+		wantH      int
+		wantThumbW int
+		wantThumbH int
+		redAtX     int
+		redAtY     int
+		blueAtX    int
+		blueAtY    int
+	}{
+		{name: "no exif", data: plain, wantOrient: 1, wantW: 40, wantH: 20, wantThumbW: 10, wantThumbH: 5, redAtX: 5, redAtY: 10, blueAtX: 35, blueAtY: 10},
+		{name: "upright with gps", data: withEXIF(plain, exifSegment(1)), wantOrient: 1, wantW: 40, wantH: 20, wantThumbW: 10, wantThumbH: 5, redAtX: 5, redAtY: 10, blueAtX: 35, blueAtY: 10},
+		{name: "rotated 180", data: withEXIF(plain, exifSegment(3)), wantOrient: 3, wantW: 40, wantH: 20, wantThumbW: 10, wantThumbH: 5, redAtX: 35, redAtY: 10, blueAtX: 5, blueAtY: 10},
+		{name: "needs 90 clockwise", data: withEXIF(plain, exifSegment(6)), wantOrient: 6, wantW: 20, wantH: 40, wantThumbW: 5, wantThumbH: 10, redAtX: 10, redAtY: 5, blueAtX: 10, blueAtY: 35},
+		{name: "needs 90 counter-clockwise", data: withEXIF(plain, exifSegment(8)), wantOrient: 8, wantW: 20, wantH: 40, wantThumbW: 5, wantThumbH: 10, redAtX: 10, redAtY: 35, blueAtX: 10, blueAtY: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jpegOrientation(tt.data); got != tt.wantOrient {
+				t.Fatalf("orientation of input = %d, want %d", got, tt.wantOrient)
+			}
+
+			full, thumb, err := Process(tt.data, 100, 10)
+			if err != nil {
+				t.Fatalf("process: %v", err)
+			}
+
+			for name, out := range map[string]*Image{"full": full, "thumb": thumb} {
+				if bytes.IndexByte(segmentMarkers(t, out.Data), 0xE1) >= 0 {
+					t.Fatalf("%s still has an APP1 segment", name)
+				}
+				if bytes.Contains(out.Data, []byte("Exif")) {
+					t.Fatalf("%s still contains EXIF data", name)
+				}
+			}
+
+			if full.Width != tt.wantW || full.Height != tt.wantH {
+				t.Fatalf("full = %dx%d, want %dx%d", full.Width, full.Height, tt.wantW, tt.wantH)
+			}
+			if thumb.Width != tt.wantThumbW || thumb.Height != tt.wantThumbH {
+				t.Fatalf("thumb = %dx%d, want %dx%d", thumb.Width, thumb.Height, tt.wantThumbW, tt.wantThumbH)
+			}
+
+			img, err := jpeg.Decode(bytes.NewReader(full.Data))
+			if err != nil {
+				t.Fatalf("decode output: %v", err)
+			}
+			if b := img.Bounds(); b.Dx() != tt.wantW || b.Dy() != tt.wantH {
+				t.Fatalf("decoded output = %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantW, tt.wantH)
+			}
+			if !isRed(img.At(tt.redAtX, tt.redAtY)) {
+				t.Fatalf("pixel (%d,%d) = %v, want red", tt.redAtX, tt.redAtY, img.At(tt.redAtX, tt.redAtY))
+			}
+			if isRed(img.At(tt.blueAtX, tt.blueAtY)) {
+				t.Fatalf("pixel (%d,%d) is red, want blue", tt.blueAtX, tt.blueAtY)
+			}
+		})
+	}
+}
@@ -13,10 +13,12 @@ var (
 	ErrExpiredToken = errors.New("token has expired")
 )
 
-// Claims represents JWT claims
+// Claims represents JWT claims.
+// SessionID is set on access tokens and points to the session they were issued for.
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Role   string    `json:"role"`
+	UserID    int    `json:"user_id"`
+	SessionID int    `json:"sid,omitempty"`
+	Role      string `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -38,11 +40,22 @@ func NewTokenManager(accessSecret, refreshSecret string, accessExpiryMin, refres
 	}
 }
 
-// GenerateAccessToken generates a new access token
-func (tm *TokenManager) GenerateAccessToken(userID uuid.UUID, role string) (string, error) {
+// AccessExpiry returns access token lifetime
+func (tm *TokenManager) AccessExpiry() time.Duration {
+	return tm.accessExpiry
+}
+
+// RefreshExpiry returns refresh token lifetime
+func (tm *TokenManager) RefreshExpiry() time.Duration {
+	return tm.refreshExpiry
+}
+
+// GenerateAccessToken generates a new access token bound to a session
+func (tm *TokenManager) GenerateAccessToken(userID, sessionID int, role string) (string, error) {
 	claims := Claims{
-		UserID: userID,
-		Role:   role,
+		UserID:    userID,
+		SessionID: sessionID,
+		Role:      role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tm.accessExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -53,12 +66,14 @@ func (tm *TokenManager) GenerateAccessToken(userID uuid.UUID, role string) (stri
 	return token.SignedString([]byte(tm.accessSecret))
 }
 
-// GenerateRefreshToken generates a new refresh token
-func (tm *TokenManager) GenerateRefreshToken(userID uuid.UUID, role string) (string, error) {
+// GenerateRefreshToken generates a new refresh token.
+// Every token gets a unique ID so that tokens issued in the same second differ.
+func (tm *TokenManager) GenerateRefreshToken(userID int, role string) (string, error) {
 	claims := Claims{
 		UserID: userID,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tm.refreshExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -104,15 +119,15 @@ func (tm *TokenManager) validateToken(tokenString, secret string) (*Claims, erro
 
 // ExtractUserID extracts user ID from access token without validation
 // Use only for non-critical operations
-func (tm *TokenManager) ExtractUserID(tokenString string) (uuid.UUID, error) {
+func (tm *TokenManager) ExtractUserID(tokenString string) (int, error) {
 	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
 	if err != nil {
-		return uuid.Nil, err
+		return 0, err
 	}
 
 	claims, ok := token.Claims.(*Claims)
 	if !ok {
-		return uuid.Nil, ErrInvalidToken
+		return 0, ErrInvalidToken
 	}
 
 	return claims.UserID, nil
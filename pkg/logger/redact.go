@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// Query parameters and key=value pairs carrying credentials, e.g. VK API
+	// URLs with access_token or launch params with sign
+	secretParamPattern = regexp.MustCompile(`(?i)\b((?:access_token|refresh_token|token|sign|secret|password|api_key|key)=)[^&\s"']+`)
+	bearerPattern      = regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// Redact masks credentials inside free text such as URLs and error messages
+func Redact(s string) string {
+	s = secretParamPattern.ReplaceAllString(s, "${1}"+redacted)
+	return bearerPattern.ReplaceAllString(s, "${1}"+redacted)
+}
+
+// isSensitiveKey reports whether an attribute name denotes a secret value
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	switch key {
+	case "sign", "signature", "authorization", "cookie":
+		return true
+	}
+	for _, part := range []string{"token", "secret", "password", "api_key", "apikey"} {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactAttr masks sensitive attributes by name and scrubs credentials
+// from string and error values
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if isSensitiveKey(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(Redact(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			a.Value = slog.StringValue(Redact(err.Error()))
+		}
+	}
+	return a
+}
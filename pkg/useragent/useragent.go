@@ -0,0 +1,82 @@
+package useragent
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Platforms
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+	PlatformWindows = "windows"
+	PlatformMacOS   = "macos"
+	PlatformLinux   = "linux"
+	PlatformUnknown = "unknown"
+)
+
+// Info represents parsed User-Agent
+type Info struct {
+	Platform   string `json:"platform"`
+	App        string `json:"app"`
+	AppVersion string `json:"app_version"`
+}
+
+// apps are checked in order, VK clients first since their webviews also report a browser
+var apps = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"VK Android", regexp.MustCompile(`VKAndroidApp/([\w.\-]+)`)},
+	{"VK iOS", regexp.MustCompile(`(?:com\.vk\.vkclient|VKiOSApp)/([\w.\-]+)`)},
+	{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/([\d.]+)`)},
+	{"Opera", regexp.MustCompile(`OPR/([\d.]+)`)},
+	{"Yandex Browser", regexp.MustCompile(`YaBrowser/([\d.]+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/([\d.]+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/([\d.]+)`)},
+	{"Safari", regexp.MustCompile(`Version/([\d.]+).*Safari/`)},
+}
+
+// Parse extracts platform, app and app version from a User-Agent string.
+// Unknown values are left empty, platform falls back to "unknown".
+func Parse(ua string) Info {
+	info := Info{
+		Platform: parsePlatform(ua),
+	}
+
+	for _, app := range apps {
+		if m := app.pattern.FindStringSubmatch(ua); m != nil {
+			info.App = app.name
+			info.AppVersion = m[1]
+			return info
+		}
+	}
+
+	// Fall back to the first product token, e.g. "okhttp/4.9.0"
+	if product := strings.Fields(ua); len(product) > 0 {
+		if name, version, ok := strings.Cut(product[0], "/"); ok && name != "Mozilla" {
+			info.App = name
+			info.AppVersion = version
+		}
+	}
+
+	return info
+}
+
+func parsePlatform(ua string) string {
+	switch {
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"), strings.Contains(ua, "iOS"),
+		strings.Contains(ua, "com.vk.vkclient"):
+		return PlatformIOS
+	case strings.Contains(ua, "Android"):
+		return PlatformAndroid
+	case strings.Contains(ua, "Windows"):
+		return PlatformWindows
+	case strings.Contains(ua, "Mac OS X"), strings.Contains(ua, "Macintosh"):
+		return PlatformMacOS
+	case strings.Contains(ua, "Linux"):
+		return PlatformLinux
+	default:
+		return PlatformUnknown
+	}
+}
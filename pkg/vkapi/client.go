@@ -1,30 +1,78 @@
 package vkapi
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
 	apiVersion = "5.131"
-	apiBaseURL = "https://api.vk.com/method"
+	// DefaultBaseURL is the public VK API
+	DefaultBaseURL = "https://api.vk.com/method"
 )
 
+// cdnDomains serve VK photos, avatars come from hosts like sun9-1.userapi.com
+var cdnDomains = []string{"userapi.com", "vk.com", "vk.me"}
+
+// ErrUntrustedURL is returned by Download for URLs outside the VK CDN
+var ErrUntrustedURL = errors.New("URL is not on the VK CDN")
+
 // Client represents VK API client
 type Client struct {
 	httpClient *http.Client
+	baseURL    string
+	// fileHost is also trusted for downloads when a fake API is used
+	fileHost string
 }
 
-// NewClient creates new VK API client
-func NewClient() *Client {
-	return &Client{
+// NewClient creates new VK API client. An empty baseURL uses the public
+// VK API, tests point it at a fake server.
+func NewClient(baseURL string) *Client {
+	client := &Client{
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		baseURL: strings.TrimRight(DefaultBaseURL, "/"),
+	}
+	if baseURL != "" && baseURL != DefaultBaseURL {
+		client.baseURL = strings.TrimRight(baseURL, "/")
+		if parsed, err := url.Parse(baseURL); err == nil {
+			client.fileHost = parsed.Host
+		}
+	}
+	return client
+}
+
+// IsCDNURL reports whether fileURL points to the VK CDN over HTTPS, or to the
+// host of a fake API the client was created with
+func (c *Client) IsCDNURL(fileURL string) bool {
+	parsed, err := url.Parse(fileURL)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	if c.fileHost != "" && parsed.Host == c.fileHost {
+		return true
+	}
+	if parsed.Scheme != "https" || parsed.Port() != "" {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	for _, domain := range cdnDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
 	}
+	return false
 }
 
 // VKUserInfo represents VK user information
@@ -42,6 +90,30 @@ type VKUserInfo struct {
 	Sex       int    `json:"sex"` // 0=not specified, 1=female, 2=male
 }
 
+// AvatarURL returns the largest avatar, empty when the user only has VK's
+// "no photo" placeholder
+func (u *VKUserInfo) AvatarURL() string {
+	for _, photoURL := range []string{u.Photo400, u.Photo200} {
+		if photoURL != "" && !strings.Contains(photoURL, "/images/camera_") {
+			return photoURL
+		}
+	}
+	return ""
+}
+
+// DisplayName joins first and last name
+func (u *VKUserInfo) DisplayName() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// CityTitle returns the city name, empty when it is not set
+func (u *VKUserInfo) CityTitle() string {
+	if u.City == nil {
+		return ""
+	}
+	return u.City.Title
+}
+
 // VKAPIResponse represents standard VK API response
 type VKAPIResponse struct {
 	Response []VKUserInfo `json:"response"`
@@ -52,18 +124,26 @@ type VKAPIResponse struct {
 }
 
 // GetUserInfo fetches user information from VK API
-func (c *Client) GetUserInfo(accessToken string, userID int) (*VKUserInfo, error) {
+func (c *Client) GetUserInfo(ctx context.Context, accessToken string, userID int) (_ *VKUserInfo, err error) {
+	ctx, span := startSpan(ctx, "VK users.get", attribute.Int("vk.user_id", userID))
+	defer func() { endSpan(span, err) }()
+
 	params := url.Values{}
 	params.Set("user_ids", fmt.Sprintf("%d", userID))
 	params.Set("fields", "photo_200,photo_400_orig,city,bdate,sex")
 	params.Set("access_token", accessToken)
 	params.Set("v", apiVersion)
 
-	apiURL := fmt.Sprintf("%s/users.get?%s", apiBaseURL, params.Encode())
+	apiURL := fmt.Sprintf("%s/users.get?%s", c.baseURL, params.Encode())
 
-	resp, err := c.httpClient.Get(apiURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build VK API request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call VK API: %w", err)
+		return nil, fmt.Errorf("failed to call VK API: %w", withoutURL(err))
 	}
 	defer resp.Body.Close()
 
@@ -87,6 +167,45 @@ func (c *Client) GetUserInfo(accessToken string, userID int) (*VKUserInfo, error
 	return &apiResp.Response[0], nil
 }
 
+// Download fetches a file such as an avatar from the VK CDN, failing if it is
+// larger than maxBytes. Other URLs are refused with ErrUntrustedURL.
+func (c *Client) Download(ctx context.Context, fileURL string, maxBytes int64) (_ []byte, err error) {
+	ctx, span := startSpan(ctx, "VK download")
+	defer func() { endSpan(span, err) }()
+	if parsed, parseErr := url.Parse(fileURL); parseErr == nil {
+		span.SetAttributes(attribute.String("server.address", parsed.Host))
+	}
+
+	if !c.IsCDNURL(fileURL) {
+		return nil, ErrUntrustedURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build download request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxBytes)
+	}
+
+	return data, nil
+}
+
 // GetUserWall fetches user's wall posts
 func (c *Client) GetUserWall(accessToken string, userID int, count int) ([]map[string]interface{}, error) {
 	params := url.Values{}
@@ -95,11 +214,11 @@ func (c *Client) GetUserWall(accessToken string, userID int, count int) ([]map[s
 	params.Set("access_token", accessToken)
 	params.Set("v", apiVersion)
 
-	apiURL := fmt.Sprintf("%s/wall.get?%s", apiBaseURL, params.Encode())
+	apiURL := fmt.Sprintf("%s/wall.get?%s", c.baseURL, params.Encode())
 
 	resp, err := c.httpClient.Get(apiURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call VK API: %w", err)
+		return nil, fmt.Errorf("failed to call VK API: %w", withoutURL(err))
 	}
 	defer resp.Body.Close()
 
@@ -140,11 +259,11 @@ func (c *Client) GetUserGroups(accessToken string, userID int) ([]map[string]int
 	params.Set("access_token", accessToken)
 	params.Set("v", apiVersion)
 
-	apiURL := fmt.Sprintf("%s/groups.get?%s", apiBaseURL, params.Encode())
+	apiURL := fmt.Sprintf("%s/groups.get?%s", c.baseURL, params.Encode())
 
 	resp, err := c.httpClient.Get(apiURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call VK API: %w", err)
+		return nil, fmt.Errorf("failed to call VK API: %w", withoutURL(err))
 	}
 	defer resp.Body.Close()
 
@@ -176,3 +295,13 @@ func (c *Client) GetUserGroups(accessToken string, userID int) ([]map[string]int
 
 	return groups, nil
 }
+
+// withoutURL drops the request URL from transport errors, it carries the
+// access token in the query string
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,33 @@
+package vkapi
+
+import "testing"
+
+func TestClientIsCDNURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		url     string
+		want    bool
+	}{
+		{name: "userapi avatar", url: "https://sun9-1.userapi.com/impg/a.jpg?size=400x400&sign=x", want: true},
+		{name: "vk.com", url: "https://vk.com/images/a.jpg", want: true},
+		{name: "vk.me subdomain", url: "https://pp.vk.me/a.jpg", want: true},
+		{name: "plain http", url: "http://sun9-1.userapi.com/a.jpg"},
+		{name: "custom port", url: "https://sun9-1.userapi.com:8443/a.jpg"},
+		{name: "lookalike domain", url: "https://evilvk.com/a.jpg"},
+		{name: "suffix in path", url: "https://evil.example/userapi.com/a.jpg"},
+		{name: "internal address", url: "http://169.254.169.254/latest/meta-data"},
+		{name: "not a URL", url: "::"},
+		{name: "fake API host", baseURL: "http://127.0.0.1:8080/method", url: "http://127.0.0.1:8080/a.jpg", want: true},
+		{name: "other host with fake API", baseURL: "http://127.0.0.1:8080/method", url: "http://127.0.0.1:9090/a.jpg"},
+		{name: "default API host is not a CDN", baseURL: DefaultBaseURL, url: "http://api.vk.com/a.jpg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewClient(tt.baseURL).IsCDNURL(tt.url); got != tt.want {
+				t.Fatalf("IsCDNURL(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
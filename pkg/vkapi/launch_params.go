@@ -0,0 +1,94 @@
+package vkapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingSign         = errors.New("launch params sign is missing")
+	ErrInvalidSign         = errors.New("launch params sign mismatch")
+	ErrAppIDMismatch       = errors.New("launch params vk_app_id mismatch")
+	ErrInvalidTimestamp    = errors.New("launch params vk_ts is invalid")
+	ErrLaunchParamsExpired = errors.New("launch params are too old")
+)
+
+// maxClockSkew tolerates clients whose clock runs slightly ahead of the server
+const maxClockSkew = 5 * time.Minute
+
+// LaunchParamsVerifier verifies VK Mini App launch params
+// https://dev.vk.com/mini-apps/development/launch-params-sign
+type LaunchParamsVerifier struct {
+	secret string
+	appID  int
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewLaunchParamsVerifier creates a verifier for the app's secret key and ID.
+// maxAge 0 disables the vk_ts check.
+func NewLaunchParamsVerifier(secret string, appID int, maxAge time.Duration) *LaunchParamsVerifier {
+	return &LaunchParamsVerifier{
+		secret: secret,
+		appID:  appID,
+		maxAge: maxAge,
+		now:    time.Now,
+	}
+}
+
+// Verify checks the sign, vk_app_id and vk_ts freshness of launch params
+func (v *LaunchParamsVerifier) Verify(params map[string]string) error {
+	sign := params["sign"]
+	if sign == "" {
+		return ErrMissingSign
+	}
+
+	expected := Sign(params, v.secret)
+	if !hmac.Equal([]byte(sign), []byte(expected)) {
+		return ErrInvalidSign
+	}
+
+	if params["vk_app_id"] != strconv.Itoa(v.appID) {
+		return ErrAppIDMismatch
+	}
+
+	if v.maxAge > 0 {
+		ts, err := strconv.ParseInt(params["vk_ts"], 10, 64)
+		if err != nil || ts <= 0 {
+			return ErrInvalidTimestamp
+		}
+
+		issuedAt := time.Unix(ts, 0)
+		now := v.now()
+		if now.Sub(issuedAt) > v.maxAge {
+			return ErrLaunchParamsExpired
+		}
+		if issuedAt.Sub(now) > maxClockSkew {
+			return ErrInvalidTimestamp
+		}
+	}
+
+	return nil
+}
+
+// Sign calculates the launch params signature: HMAC-SHA256 over the sorted,
+// URL-encoded vk_* params, encoded as unpadded URL-safe base64.
+func Sign(params map[string]string, secret string) string {
+	values := url.Values{}
+	for k, v := range params {
+		if strings.HasPrefix(k, "vk_") {
+			values.Set(k, v)
+		}
+	}
+
+	// Encode sorts by key
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(values.Encode()))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,159 @@
+package vkapi
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Example from https://dev.vk.com/mini-apps/development/launch-params-sign
+const (
+	docSecret = "wvl68m4dR1UpLrVRli"
+	docQuery  = "vk_user_id=494075&vk_app_id=6736218&vk_is_app_user=1&vk_are_notifications_enabled=1" +
+		"&vk_language=ru&vk_access_token_settings=&vk_platform=android" +
+		"&sign=htQFduJpLxz7ribXRZpDFUH-XEUhC9rBPTJkjUFEkRA"
+	docAppID = 6736218
+)
+
+func parseQuery(t *testing.T, query string) map[string]string {
+	t.Helper()
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		t.Fatalf("parse query: %v", err)
+	}
+	params := make(map[string]string, len(values))
+	for k := range values {
+		params[k] = values.Get(k)
+	}
+	return params
+}
+
+func TestSignMatchesDocumentedExample(t *testing.T) {
+	params := parseQuery(t, docQuery)
+
+	if got, want := Sign(params, docSecret), params["sign"]; got != want {
+		t.Fatalf("Sign() = %q, want %q", got, want)
+	}
+}
+
+func TestLaunchParamsVerifierVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	maxAge := time.Hour
+
+	// signed builds launch params issued at ts and signs them with the doc secret
+	signed := func(ts time.Time) map[string]string {
+		params := parseQuery(t, docQuery)
+		params["vk_ts"] = strconv.FormatInt(ts.Unix(), 10)
+		params["sign"] = Sign(params, docSecret)
+		return params
+	}
+
+	tests := []struct {
+		name   string
+		appID  int
+		maxAge time.Duration
+		params func() map[string]string
+		want   error
+	}{
+		{
+			name:   "documented example",
+			appID:  docAppID,
+			params: func() map[string]string { return parseQuery(t, docQuery) },
+		},
+		{
+			name:   "missing sign",
+			appID:  docAppID,
+			params: func() map[string]string { p := parseQuery(t, docQuery); delete(p, "sign"); return p },
+			want:   ErrMissingSign,
+		},
+		{
+			name:  "tampered sign",
+			appID: docAppID,
+			params: func() map[string]string {
+				p := parseQuery(t, docQuery)
+				p["sign"] = "AtQFduJpLxz7ribXRZpDFUH-XEUhC9rBPTJkjUFEkRA"
+				return p
+			},
+			want: ErrInvalidSign,
+		},
+		{
+			name:  "tampered user id",
+			appID: docAppID,
+			params: func() map[string]string {
+				p := parseQuery(t, docQuery)
+				p["vk_user_id"] = "1"
+				return p
+			},
+			want: ErrInvalidSign,
+		},
+		{
+			name:   "wrong app id",
+			appID:  docAppID + 1,
+			params: func() map[string]string { return parseQuery(t, docQuery) },
+			want:   ErrAppIDMismatch,
+		},
+		{
+			name:  "non vk params are ignored",
+			appID: docAppID,
+			params: func() map[string]string {
+				p := parseQuery(t, docQuery)
+				p["utm_source"] = "catalog"
+				p["ref"] = "other"
+				return p
+			},
+		},
+		{
+			name:   "fresh vk_ts",
+			appID:  docAppID,
+			maxAge: maxAge,
+			params: func() map[string]string { return signed(now.Add(-time.Minute)) },
+		},
+		{
+			name:   "missing vk_ts",
+			appID:  docAppID,
+			maxAge: maxAge,
+			params: func() map[string]string { return parseQuery(t, docQuery) },
+			want:   ErrInvalidTimestamp,
+		},
+		{
+			name:   "stale vk_ts",
+			appID:  docAppID,
+			maxAge: maxAge,
+			params: func() map[string]string { return signed(now.Add(-maxAge - time.Second)) },
+			want:   ErrLaunchParamsExpired,
+		},
+		{
+			name:   "vk_ts within clock skew",
+			appID:  docAppID,
+			maxAge: maxAge,
+			params: func() map[string]string { return signed(now.Add(maxClockSkew - time.Second)) },
+		},
+		{
+			name:   "vk_ts beyond clock skew",
+			appID:  docAppID,
+			maxAge: maxAge,
+			params: func() map[string]string { return signed(now.Add(maxClockSkew + time.Second)) },
+			want:   ErrInvalidTimestamp,
+		},
+		{
+			name:   "vk_ts check disabled",
+			appID:  docAppID,
+			params: func() map[string]string { return signed(now.Add(-24 * time.Hour)) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewLaunchParamsVerifier(docSecret, tt.appID, tt.maxAge)
+			v.now = func() time.Time { return now }
+
+			err := v.Verify(tt.params())
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}